@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+	"github.com/abyss/plan-journal-cli/pkg/hooks"
+	"github.com/abyss/plan-journal-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// NewAddCmd creates the add command
+func NewAddCmd(configFlag, locationFlag, preambleFlag *string, hookConfig *hooks.Config) *cobra.Command {
+	var target string
+
+	addCmd := &cobra.Command{
+		Use:   "add <text>...",
+		Short: "Append an entry without opening the editor",
+		Long: `Append a line to a day's entries, creating the month file and date header if needed.
+
+The words are joined with spaces into one line, so quoting is optional. The entry goes to
+today unless --date gives another day. Lines starting with "# " or "## " are rejected,
+since they would begin a new section. The post-add hook runs after the entry is written.`,
+		Example: `  plan add "* Called the bank"
+  plan add --date yesterday "* [x] Sent the report #work"`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAdd(*configFlag, *locationFlag, *preambleFlag, target, strings.Join(args, " "), *hookConfig)
+		},
+	}
+
+	addCmd.Flags().StringVar(&target, "date", "today", "Day to add the entry to (today, yesterday, tomorrow, or YYYY-MM-DD)")
+	_ = addCmd.RegisterFlagCompletionFunc("date", completeDateArg(configFlag, locationFlag, false))
+	return addCmd
+}
+
+func runAdd(configFlag, locationFlag, preambleFlag, target, text string, hookConfig hooks.Config) error {
+	// Resolve configuration
+	j, err := openJournalWithHooks(configFlag, locationFlag, preambleFlag, hookConfig)
+	if err != nil {
+		return err
+	}
+
+	// A month has no single section to append to
+	if dateutil.IsValidMonth(target) {
+		return fmt.Errorf("--date needs a day, not a month: %s", target)
+	}
+	date, err := dateutil.ParseTarget(target)
+	if err != nil {
+		return fmt.Errorf("failed to parse date: %w", err)
+	}
+
+	if err := j.Append(date, text); err != nil {
+		return fmt.Errorf("failed to add entry: %w", err)
+	}
+
+	dateStr := dateutil.FormatDate(date)
+	fmt.Println(output.Success(fmt.Sprintf("Added to %s", dateStr)))
+
+	filePath := filepath.Join(j.Dir(), dateutil.MonthFileName(date))
+	commitChanges(configFlag, j.Store(), filePath, commitMessage(dateStr, strings.Count(text, "\n")+1, 0))
+	return nil
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/browse"
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+	"github.com/abyss/plan-journal-cli/pkg/hooks"
+	"github.com/abyss/plan-journal-cli/pkg/journal"
+	"github.com/spf13/cobra"
+)
+
+// NewBrowseCmd creates the browse command
+func NewBrowseCmd(configFlag, locationFlag, editorFlag, editorTypeFlag, preambleFlag *string, hookConfig *hooks.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "browse",
+		Short: "Browse plan entries in a full-screen view",
+		Long: `Browse your journal in a full-screen terminal view, with months and days on the left
+and the selected day on the right.
+
+Keys:
+  ↑/↓ or j/k     Move the selection
+  ←/→ or h/l     Collapse or expand a month
+  Enter or e     Open the selected day in your editor
+  /              Search (filters as you type; Enter keeps the filter, Esc clears it)
+  t              Jump to today
+  J/K            Scroll the day's content
+  q              Quit`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBrowse(*configFlag, *locationFlag, *editorFlag, *editorTypeFlag, *preambleFlag, *hookConfig)
+		},
+	}
+}
+
+func runBrowse(configFlag, locationFlag, editorFlag, editorTypeFlag, preambleFlag string, hookConfig hooks.Config) error {
+	// Resolve configuration
+	j, err := openJournal(configFlag, locationFlag, preambleFlag)
+	if err != nil {
+		return err
+	}
+
+	source := browse.Source{
+		Dates: func() (map[string][]string, error) {
+			return j.Dates("")
+		},
+		Month: func(month string) (map[string]string, error) {
+			parsed, _ := time.Parse("2006-01", month)
+			parsedMonth, err := j.Month(parsed)
+			if errors.Is(err, journal.ErrNotFound) {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			days := make(map[string]string, len(parsedMonth.Days))
+			for _, day := range parsedMonth.Days {
+				if _, ok := days[day.Date]; !ok {
+					days[day.Date] = day.Text()
+				}
+			}
+			return days, nil
+		},
+	}
+
+	model, err := browse.New(source, dateutil.FormatDate(time.Now()))
+	if err != nil {
+		return fmt.Errorf("failed to load plans: %w", err)
+	}
+
+	return browse.Run(model, func(date string) error {
+		return runEdit(configFlag, locationFlag, editorFlag, editorTypeFlag, preambleFlag, date, hookConfig, editOptions{})
+	})
+}
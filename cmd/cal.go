@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/config"
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+	"github.com/abyss/plan-journal-cli/pkg/output"
+	"github.com/abyss/plan-journal-cli/pkg/pager"
+	"github.com/abyss/plan-journal-cli/pkg/planfile"
+	"github.com/spf13/cobra"
+)
+
+// calMonthsPerRow is how many months are printed side by side
+const calMonthsPerRow = 3
+
+// calMonthWidth is the visible width of one month block (7 cells of 3 characters)
+const calMonthWidth = 21
+
+// NewCalCmd creates the cal command
+func NewCalCmd(configFlag, locationFlag *string) *cobra.Command {
+	var three bool
+	var weekStartFlag string
+
+	calCmd := &cobra.Command{
+		Use:   "cal [YYYY | YYYY-MM]",
+		Short: "Show a calendar of days with entries",
+		Long: `Show a month calendar with days that have entries highlighted.
+
+Today is shown in green and days with open tasks ("- [ ]") are marked with *.
+
+Examples:
+  plan cal               # Current month
+  plan cal 2026-02       # February 2026
+  plan cal 2026          # The whole year
+  plan cal -3            # The last three months`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeFilterArg(configFlag, locationFlag, true),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target := ""
+			if len(args) > 0 {
+				target = args[0]
+			}
+			return runCal(*configFlag, *locationFlag, weekStartFlag, target, three)
+		},
+	}
+
+	calCmd.Flags().BoolVarP(&three, "three", "3", false, "Show the last three months")
+	calCmd.Flags().StringVar(&weekStartFlag, "week-start", "", "First day of the week (default: monday)")
+	_ = calCmd.RegisterFlagCompletionFunc("week-start", cobra.FixedCompletions(weekdayNames, cobra.ShellCompDirectiveNoFileComp))
+	return calCmd
+}
+
+func runCal(configFlag, locationFlag, weekStartFlag, target string, three bool) error {
+	// Resolve configuration
+	j, err := openJournal(configFlag, locationFlag, "")
+	if err != nil {
+		return err
+	}
+	weekStart, err := config.GetWeekStart(configFlag, weekStartFlag)
+	if err != nil {
+		return err
+	}
+
+	months, err := calMonths(target, three, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Collect entries for every month shown
+	days := make(map[string]planfile.DaySummary)
+	for _, month := range months {
+		summaries, err := j.Summaries(dateutil.FormatMonth(month))
+		if err != nil {
+			return fmt.Errorf("failed to discover dates: %w", err)
+		}
+		for _, day := range summaries {
+			days[day.Date] = day
+		}
+	}
+
+	today := time.Now()
+	var out strings.Builder
+	for i := 0; i < len(months); i += calMonthsPerRow {
+		if i > 0 {
+			fmt.Fprintln(&out)
+		}
+		row := months[i:min(i+calMonthsPerRow, len(months))]
+
+		blocks := make([][]string, len(row))
+		height := 0
+		for j, month := range row {
+			blocks[j] = renderCalMonth(month, weekStart, days, today)
+			height = max(height, len(blocks[j]))
+		}
+
+		for line := 0; line < height; line++ {
+			cells := make([]string, len(row))
+			for j := range row {
+				if line < len(blocks[j]) {
+					cells[j] = blocks[j][line]
+				} else {
+					cells[j] = strings.Repeat(" ", calMonthWidth)
+				}
+			}
+			fmt.Fprintln(&out, strings.TrimRight(strings.Join(cells, "  "), " "))
+		}
+	}
+
+	fmt.Fprintln(&out)
+	if output.ColorsDisabled() {
+		fmt.Fprintln(&out, output.Info("+ entry   * open tasks   < today"))
+	} else {
+		fmt.Fprintf(&out, "%s entry   %s open tasks   %s today\n",
+			output.Bold("12"), output.TaskOpen("*"), output.DateGreen("12"))
+	}
+	pager.Print(out.String())
+	return nil
+}
+
+// calMonths returns the first day of each month to show
+// An empty target means the current month; -3 shows the three months ending at the target
+func calMonths(target string, three bool, now time.Time) ([]time.Time, error) {
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	switch {
+	case target == "":
+	case len(target) == 4:
+		if three {
+			return nil, fmt.Errorf("-3 needs a month (YYYY-MM), not a year")
+		}
+		start, _, err := dateutil.FilterRange(target)
+		if err != nil {
+			return nil, err
+		}
+		months := make([]time.Time, 12)
+		for i := range months {
+			months[i] = start.AddDate(0, i, 0)
+		}
+		return months, nil
+	case dateutil.IsValidMonth(target):
+		current, _ = time.Parse("2006-01", target)
+	default:
+		return nil, fmt.Errorf("invalid target: %s (expected YYYY or YYYY-MM)", target)
+	}
+
+	if three {
+		return []time.Time{current.AddDate(0, -2, 0), current.AddDate(0, -1, 0), current}, nil
+	}
+	return []time.Time{current}, nil
+}
+
+// renderCalMonth returns the lines of one month block, each calMonthWidth wide
+func renderCalMonth(month time.Time, weekStart time.Weekday, days map[string]planfile.DaySummary, today time.Time) []string {
+	title := month.Format("January 2006")
+	padding := (calMonthWidth - len(title)) / 2
+	lines := []string{output.Header(fmt.Sprintf("%-*s", calMonthWidth, strings.Repeat(" ", padding)+title))}
+
+	var weekdays []string
+	for i := 0; i < 7; i++ {
+		weekdays = append(weekdays, time.Weekday((int(weekStart) + i) % 7).String()[:2])
+	}
+	lines = append(lines, strings.Join(weekdays, " ")+" ")
+
+	todayStr := dateutil.FormatDate(today)
+	for _, week := range dateutil.MonthGrid(month.Year(), month.Month(), weekStart) {
+		var line strings.Builder
+		for _, day := range week {
+			if day == 0 {
+				line.WriteString("   ")
+				continue
+			}
+			line.WriteString(calCell(time.Date(month.Year(), month.Month(), day, 0, 0, 0, 0, time.UTC), days, todayStr))
+		}
+		lines = append(lines, line.String())
+	}
+	return lines
+}
+
+// calCell renders a day number plus a one-character marker (3 visible characters)
+func calCell(date time.Time, days map[string]planfile.DaySummary, todayStr string) string {
+	dateStr := dateutil.FormatDate(date)
+	number := fmt.Sprintf("%2d", date.Day())
+	summary, hasEntry := days[dateStr]
+
+	marker := " "
+	switch {
+	case dateStr == todayStr && output.ColorsDisabled():
+		// Without color, today is only shown by its marker
+		marker = "<"
+	case summary.OpenTasks > 0:
+		marker = output.TaskOpen("*")
+	case hasEntry && output.ColorsDisabled():
+		// Without color, entries need a visible marker
+		marker = "+"
+	}
+
+	switch {
+	case dateStr == todayStr:
+		number = output.DateGreen(number)
+	case hasEntry:
+		number = output.Bold(number)
+	}
+	return number + marker
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/abyss/plan-journal-cli/pkg/output"
 	"github.com/spf13/cobra"
@@ -17,6 +18,8 @@ func NewColorsCmd() *cobra.Command {
 			fmt.Println(output.Header("Color Palette Test"))
 			fmt.Println()
 
+			printActiveTheme()
+
 			fmt.Println("Headers and Titles:")
 			fmt.Printf("  Header: %s\n", output.Header("This is a header"))
 			fmt.Printf("  Bold: %s\n", output.Bold("This is bold text"))
@@ -53,8 +56,59 @@ func NewColorsCmd() *cobra.Command {
 			fmt.Printf("  %s: %s\n", output.Bold("Plans Directory"), output.FilePath("~/plans"))
 			fmt.Printf("    %s: %s\n", output.Info("Source"), "config file")
 			fmt.Printf("  %s: %s %s\n", output.Bold("Config File"), output.FilePath("/path/to/config"), output.Success("(exists)"))
+			fmt.Println()
 
+			printThemes()
 			return nil
 		},
 	}
 }
+
+// roleSamples is the preview text for each theme role
+var roleSamples = map[string]string{
+	output.RoleHeader:   "## 2026-02-19 - Planning",
+	output.RoleToday:    "2026-02-19",
+	output.RolePast:     "2026-01-10",
+	output.RoleFuture:   "2026-03-15",
+	output.RoleTag:      "#work",
+	output.RoleTaskOpen: "[ ]",
+	output.RoleTaskDone: "[x]",
+}
+
+// printActiveTheme shows each role of the theme in use with its style
+func printActiveTheme() {
+	name, theme := output.ActiveTheme()
+	fmt.Printf("Theme: %s\n", output.Bold(name))
+	for _, role := range output.Roles {
+		style, err := output.ParseStyle(theme[role], output.TrueColorSupported())
+		if err != nil {
+			continue
+		}
+		spec := theme[role]
+		if spec == "" {
+			spec = "default"
+		}
+		// Pad outside the style so escape codes don't break alignment
+		sample := roleSamples[role]
+		padding := strings.Repeat(" ", 26-len(sample))
+		fmt.Printf("  %-10s %s%s %s\n", role+":", style(sample), padding, output.Info(spec))
+	}
+	fmt.Println()
+}
+
+// printThemes shows a sample line for every built-in theme
+func printThemes() {
+	fmt.Println("Built-in Themes (set PLAN_THEME):")
+	for _, name := range output.ThemeNames() {
+		theme := output.Themes[name]
+		samples := make([]string, 0, len(output.Roles))
+		for _, role := range output.Roles {
+			style, err := output.ParseStyle(theme[role], output.TrueColorSupported())
+			if err != nil {
+				continue
+			}
+			samples = append(samples, style(roleSamples[role]))
+		}
+		fmt.Printf("  %-14s %s\n", name, strings.Join(samples, " "))
+	}
+}
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/config"
+	"github.com/abyss/plan-journal-cli/pkg/output"
+	"github.com/abyss/plan-journal-cli/pkg/planfile"
+	"github.com/spf13/cobra"
+)
+
+// completionShells are the shells plan completion supports
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// weekdayNames are the --week-start completions
+var weekdayNames = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// NewCompletionCmd creates the completion command
+func NewCompletionCmd() *cobra.Command {
+	var install bool
+
+	completionCmd := &cobra.Command{
+		Use:   "completion [bash|zsh|fish]",
+		Short: "Generate or install shell completion",
+		Long: `Print the shell completion script for bash, zsh, or fish, or install it with --install.
+The shell defaults to the one in $SHELL.
+
+Completion suggests date keywords, months and days with entries (with their weekday and
+title), plan filenames for format, and values for flags such as --editor.
+
+Install locations:
+  bash: ~/.local/share/bash-completion/completions/plan (needs the bash-completion package)
+  zsh:  ~/.zfunc/_plan (add ~/.zfunc to fpath before compinit)
+  fish: ~/.config/fish/completions/plan.fish
+
+Examples:
+  plan completion --install         # Install for the current shell
+  plan completion zsh > _plan       # Write the zsh script yourself
+  source <(plan completion bash)    # Enable for the current bash session`,
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: completionShells,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			shell := ""
+			if len(args) > 0 {
+				shell = args[0]
+			}
+			return runCompletion(cmd.Root(), shell, install)
+		},
+	}
+
+	completionCmd.Flags().BoolVar(&install, "install", false, "Install the script where the shell loads completions from")
+	return completionCmd
+}
+
+func runCompletion(root *cobra.Command, shell string, install bool) error {
+	if shell == "" {
+		shell = filepath.Base(os.Getenv("SHELL"))
+		if !slices.Contains(completionShells, shell) {
+			return fmt.Errorf("can't detect a supported shell from $SHELL, specify one of: %s", strings.Join(completionShells, ", "))
+		}
+	}
+
+	if !install {
+		return writeCompletionScript(root, shell, os.Stdout)
+	}
+
+	path, err := completionInstallPath(root.Name(), shell)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create completion directory: %w", err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to write completion script: %w", err)
+	}
+	defer file.Close()
+
+	if err := writeCompletionScript(root, shell, file); err != nil {
+		return err
+	}
+
+	fmt.Println(output.Success(fmt.Sprintf("Installed %s completion to %s", shell, path)))
+	switch shell {
+	case "bash":
+		fmt.Println(output.Info("Open a new shell to use it (requires the bash-completion package)"))
+	case "zsh":
+		fmt.Println(output.Info("Make sure ~/.zshrc has 'fpath=(~/.zfunc $fpath)' before 'compinit', then open a new shell"))
+	case "fish":
+		fmt.Println(output.Info("Open a new shell to use it"))
+	}
+	return nil
+}
+
+// writeCompletionScript writes the completion script for shell, with descriptions
+func writeCompletionScript(root *cobra.Command, shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletionV2(w, true)
+	case "zsh":
+		return root.GenZshCompletion(w)
+	case "fish":
+		return root.GenFishCompletion(w, true)
+	}
+	return fmt.Errorf("unsupported shell: %s (expected %s)", shell, strings.Join(completionShells, ", "))
+}
+
+// completionInstallPath returns where a shell loads user completion scripts from
+func completionInstallPath(name, shell string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+
+	switch shell {
+	case "bash":
+		dataDir := os.Getenv("XDG_DATA_HOME")
+		if dataDir == "" {
+			dataDir = filepath.Join(homeDir, ".local", "share")
+		}
+		return filepath.Join(dataDir, "bash-completion", "completions", name), nil
+	case "zsh":
+		return filepath.Join(homeDir, ".zfunc", "_"+name), nil
+	case "fish":
+		configDir := os.Getenv("XDG_CONFIG_HOME")
+		if configDir == "" {
+			configDir = filepath.Join(homeDir, ".config")
+		}
+		return filepath.Join(configDir, "fish", "completions", name+".fish"), nil
+	}
+	return "", fmt.Errorf("unsupported shell: %s (expected %s)", shell, strings.Join(completionShells, ", "))
+}
+
+// RegisterGlobalFlagCompletions adds value completion for the global flags
+func RegisterGlobalFlagCompletions(root *cobra.Command, configFlag *string) {
+	flags := map[string]cobra.CompletionFunc{
+		"location": func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		},
+		"editor": func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			editors := config.GetEditors(*configFlag)
+			var completions []cobra.Completion
+			for _, name := range config.EditorNames(editors) {
+				tmpl := editors[name]
+				description := "gui"
+				if tmpl.Terminal {
+					description = "terminal"
+				}
+				if !tmpl.Installed() {
+					description += ", not installed"
+				}
+				completions = append(completions, cobra.CompletionWithDesc(name, description))
+			}
+			return completions, cobra.ShellCompDirectiveNoFileComp
+		},
+		"editor-type": cobra.FixedCompletions([]cobra.Completion{"terminal", "gui", "auto"}, cobra.ShellCompDirectiveNoFileComp),
+		"preamble":    cobra.NoFileCompletions,
+		"no-color":    cobra.FixedCompletions([]cobra.Completion{"true", "false"}, cobra.ShellCompDirectiveNoFileComp),
+		"no-hooks":    cobra.FixedCompletions([]cobra.Completion{"true", "false"}, cobra.ShellCompDirectiveNoFileComp),
+		"no-pager":    cobra.FixedCompletions([]cobra.Completion{"true", "false"}, cobra.ShellCompDirectiveNoFileComp),
+	}
+	for name, complete := range flags {
+		_ = root.RegisterFlagCompletionFunc(name, complete)
+	}
+}
+
+// completeDateArg completes a single date target argument
+// Plan filenames are offered too when withFiles is set
+func completeDateArg(configFlag, locationFlag *string, withFiles bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		j, err := openJournal(*configFlag, *locationFlag, "")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		days, err := j.Summaries("")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		completions := planfile.CompleteDates(days, toComplete, time.Now())
+		if withFiles {
+			files, err := j.Store().CompleteFiles(days, toComplete)
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			completions = append(completions, files...)
+		}
+		return completionsWithDesc(completions), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeFilterArg completes a single YYYY filter argument, or YYYY-MM too when withMonths is set
+func completeFilterArg(configFlag, locationFlag *string, withMonths bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		j, err := openJournal(*configFlag, *locationFlag, "")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		days, err := j.Summaries("")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return completionsWithDesc(planfile.CompleteFilters(days, toComplete, withMonths)), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeCommaList completes a comma-separated flag value, suggesting the values not used yet
+func completeCommaList(values []string) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		used := strings.Split(toComplete, ",")
+		done := strings.Join(used[:len(used)-1], ",")
+		if done != "" {
+			done += ","
+		}
+
+		var completions []cobra.Completion
+		for _, value := range values {
+			if !slices.Contains(used[:len(used)-1], value) {
+				completions = append(completions, done+value)
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+}
+
+// completionsWithDesc converts planfile completions to cobra's value/description format
+func completionsWithDesc(completions []planfile.Completion) []cobra.Completion {
+	result := make([]cobra.Completion, 0, len(completions))
+	for _, c := range completions {
+		result = append(result, cobra.CompletionWithDesc(c.Value, c.Description))
+	}
+	return result
+}
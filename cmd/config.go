@@ -4,9 +4,11 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/abyss/plan-journal-cli/pkg/config"
+	"github.com/abyss/plan-journal-cli/pkg/editor"
 	"github.com/abyss/plan-journal-cli/pkg/output"
 	"github.com/spf13/cobra"
 )
@@ -47,10 +49,24 @@ func runConfig(configFlag, locationFlag, editorFlag, editorTypeFlag, preambleFla
 	// Editor
 	fmt.Printf("%s: %s\n", output.Bold("Editor"), editorCmd)
 	fmt.Printf("  %s: %s\n", output.Info("Source"), getEditorSource(configFlag, editorFlag))
+	if !editor.IsAvailable(editorCmd) {
+		fmt.Printf("  %s: %s\n", output.Warning("Warning"), "not found on PATH")
+	}
+	fallbacks := make([]string, 0)
+	for _, fallback := range config.GetEditorFallbacks(configFlag) {
+		fallbacks = append(fallbacks, config.CommandBinary(fallback))
+	}
+	fmt.Printf("  %s: %s (%s)\n", output.Info("Fallbacks"), strings.Join(fallbacks, ", "), getEditorFallbackSource(configFlag))
 	fmt.Println()
 
 	// Editor Type
-	fmt.Printf("%s: %s\n", output.Bold("Editor Type"), editorType)
+	editorTypeDisplay := editorType
+	if editorType == "auto" {
+		if detected := config.EditorTypeForCommand(configFlag, editorCmd); detected != "auto" {
+			editorTypeDisplay = fmt.Sprintf("auto (detected: %s)", detected)
+		}
+	}
+	fmt.Printf("%s: %s\n", output.Bold("Editor Type"), editorTypeDisplay)
 	fmt.Printf("  %s: %s\n", output.Info("Source"), getEditorTypeSource(configFlag, editorTypeFlag))
 	fmt.Println()
 
@@ -81,9 +97,49 @@ func runConfig(configFlag, locationFlag, editorFlag, editorTypeFlag, preambleFla
 	}
 	fmt.Printf("  %s: %s\n", output.Info("Source"), getConfigFileSource(configFlag))
 
+	// Project-local journal
+	if project := config.DiscoverProject(); project != nil {
+		fmt.Println()
+		printProject(configFlag, project, configPath, plansDir)
+	}
+
 	return nil
 }
 
+// printProject reports the discovered project-local markers and which of them won
+func printProject(configFlag string, project *config.Project, configPath, plansDir string) {
+	fmt.Printf("%s: %s\n", output.Bold("Project Journal"), output.FilePath(project.Root))
+	if project.Trusted(configFlag) {
+		fmt.Printf("  %s: %s\n", output.Info("Trust"), output.Success("trusted"))
+	} else {
+		fmt.Printf("  %s: %s\n", output.Info("Trust"), output.Warning("not trusted (run plan trust to allow its config to run commands and its hooks)"))
+	}
+	if ignored := config.GetIgnoredProjectKeys(configFlag); len(ignored) > 0 {
+		fmt.Printf("  %s: %s\n", output.Warning("Ignored"), strings.Join(ignored, ", "))
+	}
+
+	if project.ConfigPath != "" {
+		fmt.Printf("  %s: %s %s\n", output.Info(config.ProjectConfigName), output.FilePath(project.ConfigPath), projectMarkerStatus(project.ConfigPath == configPath))
+	}
+	if project.PlansDir != "" {
+		fmt.Printf("  %s: %s %s\n", output.Info(config.ProjectPlansDirName+"/"), output.FilePath(project.PlansDir), projectMarkerStatus(project.PlansDir == plansDir))
+		if project.ConfigPath == "" {
+			nested := project.ProjectConfigFile()
+			if nested != "" {
+				fmt.Printf("  %s: %s %s\n", output.Info(config.ProjectPlansDirName+"/.config"), output.FilePath(nested), projectMarkerStatus(nested == configPath))
+			}
+		}
+	}
+}
+
+// projectMarkerStatus describes whether a discovered marker is in effect
+func projectMarkerStatus(inUse bool) string {
+	if inUse {
+		return output.Success("(in use)")
+	}
+	return output.Warning("(overridden)")
+}
+
 func getLocationSource(configFlag, locationFlag string) string {
 	if locationFlag != "" {
 		return "command-line flag"
@@ -91,6 +147,15 @@ func getLocationSource(configFlag, locationFlag string) string {
 	if os.Getenv("PLAN_LOCATION") != "" {
 		return "environment variable (PLAN_LOCATION)"
 	}
+	if project := config.DiscoverProject(); project != nil {
+		projectConfig := project.ProjectConfigFile()
+		if projectConfig != "" && config.GetConfigPath(configFlag) == projectConfig && hasConfigValue(configFlag, "PLAN_LOCATION") {
+			return fmt.Sprintf("project config (PLAN_LOCATION in %s)", projectConfig)
+		}
+		if project.PlansDir != "" {
+			return fmt.Sprintf("project-local (%s/ found walking up from the working directory)", config.ProjectPlansDirName)
+		}
+	}
 	if hasConfigValue(configFlag, "PLAN_LOCATION") {
 		return "config file"
 	}
@@ -107,6 +172,22 @@ func getEditorSource(configFlag, editorFlag string) string {
 	if hasConfigValue(configFlag, "PLAN_EDITOR") {
 		return "config file"
 	}
+	if os.Getenv("VISUAL") != "" {
+		return "environment variable (VISUAL)"
+	}
+	if os.Getenv("EDITOR") != "" {
+		return "environment variable (EDITOR)"
+	}
+	return "default"
+}
+
+func getEditorFallbackSource(configFlag string) string {
+	if os.Getenv("PLAN_EDITOR_FALLBACK") != "" {
+		return "environment variable (PLAN_EDITOR_FALLBACK)"
+	}
+	if hasConfigValue(configFlag, "PLAN_EDITOR_FALLBACK") {
+		return "config file"
+	}
 	return "default"
 }
 
@@ -159,10 +240,24 @@ func getConfigFileSource(configFlag string) string {
 	if os.Getenv("PLAN_CONFIG") != "" {
 		return "environment variable (PLAN_CONFIG)"
 	}
+	if project := config.DiscoverProject(); project != nil {
+		if projectConfig := project.ProjectConfigFile(); projectConfig != "" {
+			marker := config.ProjectConfigName
+			if projectConfig != project.ConfigPath {
+				marker = config.ProjectPlansDirName + "/.config"
+			}
+			return fmt.Sprintf("project-local (%s found walking up from the working directory)", marker)
+		}
+	}
 	return "default"
 }
 
 func hasConfigValue(configFlag, key string) bool {
+	// Keys an untrusted project can't set don't count
+	if slices.Contains(config.GetIgnoredProjectKeys(configFlag), key) {
+		return false
+	}
+
 	configPath := config.GetConfigPath(configFlag)
 	file, err := os.Open(configPath)
 	if err != nil {
@@ -1,69 +1,485 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/abyss/plan-journal-cli/pkg/config"
 	"github.com/abyss/plan-journal-cli/pkg/dateutil"
 	"github.com/abyss/plan-journal-cli/pkg/editor"
+	"github.com/abyss/plan-journal-cli/pkg/hooks"
 	"github.com/abyss/plan-journal-cli/pkg/output"
 	"github.com/abyss/plan-journal-cli/pkg/planfile"
 	"github.com/spf13/cobra"
 )
 
 // NewEditCmd creates the edit command
-func NewEditCmd(configFlag, locationFlag, editorFlag, editorTypeFlag, preambleFlag *string) *cobra.Command {
-	return &cobra.Command{
+func NewEditCmd(configFlag, locationFlag, editorFlag, editorTypeFlag, preambleFlag *string, hookConfig *hooks.Config) *cobra.Command {
+	var opts editOptions
+
+	editCmd := &cobra.Command{
 		Use:     "edit <target>",
 		Aliases: []string{"open"},
 		Short:   "Open a plan entry in editor",
-		Long:    "Opens a plan file with cursor positioned at the specified date entry. Target can be 'yesterday', 'today', 'tomorrow', or a specific date (YYYY-MM-DD)",
-		Args:    cobra.ExactArgs(1),
+		Long: `Opens a plan file with cursor positioned at the specified date entry. Target can be 'yesterday', 'today', 'tomorrow', a specific date (YYYY-MM-DD), or a month (YYYY-MM)
+
+A month target opens the month file at the end (or at the preamble with PLAN_CURSOR=top)
+without adding a day.
+
+With --no-create, nothing is added to the file: the month file must already exist, and a
+missing day opens at the end of the file instead of creating its header.
+
+With --scoped, only that date's section is opened in a temporary file and merged back
+when the editor exits, so other days can't be changed by accident. It also keeps entries
+added by plan add or plan serve while the editor is open, which an editor writing the
+whole month file could overwrite. If the day itself was rewritten meanwhile, your edit is
+saved as a .rej file instead (encrypted journals do the same for any conflicting change).`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDateArg(configFlag, locationFlag, false),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runEdit(*configFlag, *locationFlag, *editorFlag, *editorTypeFlag, *preambleFlag, args[0])
+			return runEdit(*configFlag, *locationFlag, *editorFlag, *editorTypeFlag, *preambleFlag, args[0], *hookConfig, opts)
 		},
 	}
+
+	editCmd.Flags().BoolVar(&opts.scoped, "scoped", false, "Edit only this date's section in a temporary file")
+	editCmd.Flags().BoolVar(&opts.noCreate, "no-create", false, "Don't create the month file or date header")
+	return editCmd
+}
+
+// editOptions holds per-command options for runEdit
+type editOptions struct {
+	scoped   bool // Edit the date section in isolation
+	noCreate bool // Open the file as-is without adding the month file or date header
 }
 
-func runEdit(configFlag, locationFlag, editorFlag, editorTypeFlag, preambleFlag, target string) error {
+// editScope is the part of a month file an edit is about: one day or the whole month
+type editScope struct {
+	date     time.Time
+	label    string // YYYY-MM-DD, or YYYY-MM for a month-level edit
+	month    bool
+	noCreate bool
+}
+
+// lines returns the scope's current content for change summaries
+func (s editScope) lines(store *planfile.Store, filePath string) ([]string, error) {
+	if s.month {
+		return store.FileLines(filePath)
+	}
+	return store.DateSectionLines(filePath, s.label)
+}
+
+func runEdit(configFlag, locationFlag, editorFlag, editorTypeFlag, preambleFlag, target string, hookConfig hooks.Config, opts editOptions) error {
 	// Resolve configuration
 	plansDir := config.GetPlansDirectory(configFlag, locationFlag)
-	editorCmd, err := config.GetEditorCommand(configFlag, editorFlag)
+	store := &planfile.Store{Dir: plansDir, Passphrase: askPassphrase}
+	preferredCmd, err := config.GetEditorCommand(configFlag, editorFlag)
 	if err != nil {
 		return fmt.Errorf("failed to resolve editor: %w", err)
 	}
+
+	// Fall back through the configured list if the editor isn't installed
+	editorCmd, err := editor.SelectAvailable(preferredCmd, config.GetEditorFallbacks(configFlag))
+	if err != nil {
+		return err
+	}
+	if editorCmd != preferredCmd {
+		fmt.Println(output.Warning(fmt.Sprintf("Editor '%s' not found on PATH, falling back to '%s'",
+			config.CommandBinary(preferredCmd), config.CommandBinary(editorCmd))))
+	}
+
 	editorType := config.GetEditorType(configFlag, editorTypeFlag)
+	if editorType == "auto" {
+		// Use the type declared by the editor registry when the binary is known
+		editorType = config.EditorTypeForCommand(configFlag, editorCmd)
+	}
 	preamble := config.GetPreamble(configFlag, preambleFlag)
 
-	// Parse target date
+	// Encrypted files are decrypted for the editor and must be re-encrypted when it exits
+	encrypted := store.Encrypted()
+	if encrypted && !editor.WaitsForExit(editorCmd, editorType) {
+		return fmt.Errorf("encrypted journals need an editor that waits: use a terminal editor or a GUI editor with a wait flag (e.g. code --wait)")
+	}
+
+	// Parse target date (a month target edits the whole file)
 	date, err := dateutil.ParseTarget(target)
 	if err != nil {
 		return fmt.Errorf("failed to parse target: %w", err)
 	}
+	scope := editScope{date: date, label: dateutil.FormatDate(date), noCreate: opts.noCreate}
+	if dateutil.IsValidMonth(target) {
+		scope.month = true
+		scope.label = dateutil.FormatMonth(date)
+		if opts.scoped {
+			return fmt.Errorf("--scoped edits a single day, give a date instead of a month")
+		}
+	}
+
+	filePath := filepath.Join(plansDir, dateutil.MonthFileName(date))
+	_, statErr := os.Stat(store.StoredPath(filePath))
+	if statErr != nil && !os.IsNotExist(statErr) {
+		return fmt.Errorf("failed to read plan file: %w", statErr)
+	}
+	exists := statErr == nil
+	if opts.noCreate && !exists {
+		return fmt.Errorf("no plan file for %s", dateutil.FormatMonth(date))
+	}
+
+	// Pre-edit hooks can veto the edit, so they run before anything is created
+	hookCtx := hooks.Context{File: store.StoredPath(filePath), Date: scope.label}
+	if err := hookConfig.Run(hooks.PreEdit, hookCtx); err != nil {
+		return fmt.Errorf("aborted by hook: %w", err)
+	}
 
-	// Ensure month file exists with preamble
-	if err := planfile.EnsureMonthFile(date, plansDir, preamble); err != nil {
-		return fmt.Errorf("failed to ensure month file: %w", err)
+	// Snapshot the file before creating anything, so a new file or header is committed too
+	initialHash, err := store.HashFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read plan file: %w", err)
 	}
 
-	// Ensure date header exists
-	if err := planfile.EnsureDateHeader(date, plansDir); err != nil {
-		return fmt.Errorf("failed to ensure date header: %w", err)
+	var monthCreated, dayCreated bool
+	switch {
+	case opts.noCreate:
+		// The file is opened as it is
+	case scope.month:
+		// Month-level edits open the file as it is, so a hand-written preamble isn't replaced
+		if !exists {
+			if monthCreated, err = store.EnsureMonthFile(date, preamble); err != nil {
+				return fmt.Errorf("failed to ensure month file: %w", err)
+			}
+		}
+	default:
+		// Ensure month file exists with preamble
+		if monthCreated, err = store.EnsureMonthFile(date, preamble); err != nil {
+			return fmt.Errorf("failed to ensure month file: %w", err)
+		}
+
+		// Ensure date header exists
+		if dayCreated, err = store.EnsureDateHeader(date); err != nil {
+			return fmt.Errorf("failed to ensure date header: %w", err)
+		}
+	}
+	if monthCreated {
+		hookConfig.Trigger(hooks.OnMonthCreate, hooks.Context{File: hookCtx.File, Date: dateutil.FormatMonth(date)})
+	}
+	if dayCreated {
+		hookConfig.Trigger(hooks.OnDayCreate, hooks.Context{File: hookCtx.File, Date: dateutil.FormatDate(date)})
 	}
 
-	// Find insertion point
-	filePath, lineNum, err := planfile.FindInsertionPoint(date, plansDir)
+	// Snapshot the file so changes can be detected after the editor exits
+	beforeHash, err := store.HashFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read plan file: %w", err)
+	}
+	beforeLines, err := scope.lines(store, filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read date section: %w", err)
+	}
+
+	headerLine := 1
+	if !scope.month {
+		headerLine, err = store.FindDateSectionLine(filePath, scope.label)
+		if err != nil {
+			return fmt.Errorf("failed to find date header: %w", err)
+		}
+		if headerLine == 0 && opts.scoped {
+			return fmt.Errorf("no entry for %s", scope.label)
+		}
+	}
+
+	// Seeding adds a line, so it is skipped when the file must stay untouched
+	seed := ""
+	if !opts.noCreate {
+		seed = planfile.ExpandSeed(config.GetSeed(configFlag), time.Now())
+	}
+	editTarget := editor.Target{
+		File:     filePath,
+		Date:     dateutil.FormatDate(date),
+		Month:    dateutil.FormatMonth(date),
+		PlansDir: plansDir,
+		PrevFile: filepath.Join(plansDir, dateutil.MonthFileName(date.AddDate(0, 0, -1))),
+	}
+	if scope.month {
+		editTarget.PrevFile = filepath.Join(plansDir, dateutil.MonthFileName(date.AddDate(0, -1, 0)))
+	}
+	if encrypted {
+		// The previous file is only on disk encrypted, which an editor can't show
+		editTarget.PrevFile = ""
+	}
+
+	if opts.scoped {
+		if !editor.WaitsForExit(editorCmd, editorType) {
+			return fmt.Errorf("--scoped needs an editor that waits: use a terminal editor or a GUI editor with a wait flag (e.g. code --wait)")
+		}
+		if err := runScopedEdit(configFlag, store, hookConfig, preamble, editorCmd, editorType, seed, scope, editTarget, initialHash, beforeHash, beforeLines); err != nil {
+			return err
+		}
+		hookConfig.Trigger(hooks.PostEdit, hookCtx)
+		return nil
+	}
+
+	// Find the cursor position, seeding a new line if configured
+	cursorMode := config.GetCursorMode(configFlag)
+	var cursor planfile.CursorPosition
+	switch {
+	case scope.month:
+		cursor, err = store.MonthCursor(filePath, cursorMode)
+	case headerLine == 0:
+		// --no-create for a day that has no entry yet
+		fmt.Println(output.Info(fmt.Sprintf("No entry for %s, opening at the end of the file", scope.label)))
+		cursor, err = store.MonthCursor(filePath, planfile.CursorEnd)
+	case opts.noCreate:
+		cursor, err = store.LocateCursor(filePath, scope.label, cursorMode)
+	default:
+		filePath, cursor, err = store.PrepareCursor(date, cursorMode, seed)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to find insertion point: %w", err)
 	}
 
+	// Encrypted files are edited in a private decrypted copy
+	var plainCopy *planfile.PlainCopy
+	stopWatching := func() {}
+	if encrypted {
+		plainCopy, err = store.StartPlainCopy(filePath)
+		if err != nil {
+			_, _ = store.RemoveUntouchedSeed(filePath, cursor)
+			return fmt.Errorf("failed to decrypt plan file: %w", err)
+		}
+		editTarget.File = plainCopy.TempPath
+		stopWatching = cleanupOnSignal(plainCopy.FilePath, plainCopy.Cleanup)
+	}
+
 	// Launch editor
-	if err := editor.LaunchEditor(editorCmd, filePath, lineNum, 0, editorType); err != nil {
+	editTarget.Line = cursor.Line
+	editTarget.Column = cursor.Column
+	editTarget.HeaderLine = headerLine
+	err = editor.LaunchEditor(editorCmd, editTarget, editorType)
+	stopWatching()
+	if err != nil {
+		// The editor may have failed after the copy was saved, so keep an edited copy
+		if plainCopy != nil {
+			if modified, modErr := plainCopy.Modified(); modErr != nil || modified {
+				return fmt.Errorf("editor failed: %w (your edit is in %s)", err, plainCopy.TempPath)
+			}
+			_ = plainCopy.Cleanup()
+		}
+		// Don't leave the seeded line behind if the editor never opened
+		_, _ = store.RemoveUntouchedSeed(filePath, cursor)
 		return fmt.Errorf("failed to launch editor: %w", err)
 	}
 
 	fmt.Printf("Opened %s at line %s\n",
 		output.Bold(filePath),
-		output.Bold(fmt.Sprintf("%d", lineNum)))
+		output.Bold(fmt.Sprintf("%d", cursor.Line)))
+
+	// GUI editors that don't wait return immediately, nothing to check yet
+	if !editor.WaitsForExit(editorCmd, editorType) {
+		return nil
+	}
+
+	if plainCopy != nil {
+		// A change made while the editor was open (e.g. by plan serve) is kept, and the
+		// edit goes to a reject file instead of overwriting it
+		if err := plainCopy.Save(); errors.Is(err, planfile.ErrChangedOnDisk) {
+			rejPath, rejErr := plainCopy.Reject()
+			if rejErr != nil {
+				return fmt.Errorf("%w: %v (your edit is in %s)", err, rejErr, plainCopy.TempPath)
+			}
+			if err := plainCopy.Cleanup(); err != nil {
+				fmt.Println(output.Warning(fmt.Sprintf("Failed to remove decrypted copy: %v", err)))
+			}
+			_, _ = store.RemoveUntouchedSeed(filePath, cursor)
+			fmt.Printf("%s %s changed on disk while you were editing.\n", output.Warning("Conflict:"), output.FilePath(store.StoredPath(filePath)))
+			fmt.Printf("%s %s\n", output.Warning("Kept your edit in"), output.FilePath(rejPath))
+			return nil
+		} else if err != nil {
+			// Keep the decrypted copy if it can't be written back, so the edit isn't lost
+			return fmt.Errorf("failed to encrypt edited file: %w (your edit is in %s)", err, plainCopy.TempPath)
+		}
+		if err := plainCopy.Cleanup(); err != nil {
+			fmt.Println(output.Warning(fmt.Sprintf("Failed to remove decrypted copy: %v", err)))
+		}
+	}
+
+	// Drop the seeded line if the user didn't write anything on it
+	if _, err := store.RemoveUntouchedSeed(filePath, cursor); err != nil {
+		return fmt.Errorf("failed to clean up seeded line: %w", err)
+	}
+
+	if err := runPostEdit(configFlag, store, hookConfig, preamble, filePath, scope, initialHash, beforeHash, beforeLines); err != nil {
+		return err
+	}
+
+	hookConfig.Trigger(hooks.PostEdit, hookCtx)
+	return nil
+}
+
+// cleanupOnSignal removes the temporary copy of filePath if the process is hung up on
+// or terminated while the editor is open, so no plaintext is left behind. The returned
+// function stops watching
+func cleanupOnSignal(filePath string, cleanup func() error) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-signals:
+			_ = cleanup()
+			fmt.Fprintf(os.Stderr, "Removed the temporary copy of %s (%v)\n", filePath, sig)
+			os.Exit(1)
+		case <-done:
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// runScopedEdit edits a single date section in a temporary file and merges it back
+func runScopedEdit(configFlag string, store *planfile.Store, hookConfig hooks.Config, preamble, editorCmd, editorType, seed string, scope editScope, editTarget editor.Target, initialHash, beforeHash string, beforeLines []string) error {
+	scoped, err := store.StartScopedEdit(scope.date)
+	if err != nil {
+		return fmt.Errorf("failed to extract date section: %w", err)
+	}
+	defer scoped.Cleanup()
+
+	cursor, err := store.PrepareCursorInFile(scoped.TempPath, scoped.Date, config.GetCursorMode(configFlag), seed)
+	if err != nil {
+		return fmt.Errorf("failed to find insertion point: %w", err)
+	}
+
+	editTarget.File = scoped.TempPath
+	editTarget.Line = cursor.Line
+	editTarget.Column = cursor.Column
+	editTarget.HeaderLine = 1
+	stopWatching := cleanupOnSignal(scoped.FilePath, scoped.Cleanup)
+	err = editor.LaunchEditor(editorCmd, editTarget, editorType)
+	stopWatching()
+	if err != nil {
+		return fmt.Errorf("failed to launch editor: %w", err)
+	}
+
+	if _, err := store.RemoveUntouchedSeed(scoped.TempPath, cursor); err != nil {
+		return fmt.Errorf("failed to clean up seeded line: %w", err)
+	}
+
+	header, content, err := scoped.ReadEdited()
+	if err != nil {
+		// Keep the user's work instead of discarding it
+		rejPath, rejErr := scoped.Reject()
+		if rejErr != nil {
+			return fmt.Errorf("%w (and failed to save it: %v)", err, rejErr)
+		}
+		return fmt.Errorf("%w; your edit was saved to %s", err, rejPath)
+	}
+
+	if scoped.Modified(header, content) {
+		// Someone (or something) changed the month file while we were editing
+		conflict, err := scoped.ConflictOnDisk()
+		if err != nil {
+			return fmt.Errorf("failed to check plan file: %w", err)
+		}
+		if conflict && !confirmMerge(scoped.FilePath) {
+			rejPath, err := scoped.Reject()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s %s\n", output.Warning("Kept your edit in"), output.FilePath(rejPath))
+			return nil
+		}
+
+		if err := scoped.Merge(header, content); errors.Is(err, planfile.ErrChangedOnDisk) {
+			// The day itself was rewritten meanwhile, so merging would lose those changes
+			rejPath, err := scoped.Reject()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s %s changed on disk while you were editing.\n", output.Warning("Conflict:"), output.FilePath(scoped.FilePath))
+			fmt.Printf("%s %s\n", output.Warning("Kept your edit in"), output.FilePath(rejPath))
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to merge edited section: %w", err)
+		}
+	}
+
+	return runPostEdit(configFlag, store, hookConfig, preamble, scoped.FilePath, scope, initialHash, beforeHash, beforeLines)
+}
+
+// confirmMerge asks whether to merge a scoped edit into a month file that changed on disk
+// Without an interactive terminal the answer is no, so the edit is kept as a .rej file
+func confirmMerge(filePath string) bool {
+	fmt.Printf("%s %s changed on disk while you were editing.\n", output.Warning("Conflict:"), output.FilePath(filePath))
+	fmt.Println("Merging replaces only this day's section, keeps entries added to it meanwhile and keeps the other changes.")
+	return promptYesNo("Merge your edit into the current file? Otherwise it is kept as a .rej copy [y/N]: ")
+}
+
+// runPostEdit formats the file and summarizes changes once the editor has exited
+// initialHash is the file before the edit created anything, beforeHash the file the editor opened
+func runPostEdit(configFlag string, store *planfile.Store, hookConfig hooks.Config, preamble, filePath string, scope editScope, initialHash, beforeHash string, beforeLines []string) error {
+	dateStr := scope.label
+
+	afterHash, err := store.HashFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read plan file: %w", err)
+	}
+
+	if afterHash != beforeHash {
+		// Month-level and --no-create edits keep the preamble as written in the file
+		if scope.month || scope.noCreate {
+			pf, err := store.ParseFile(filePath)
+			if err != nil {
+				return fmt.Errorf("failed to parse plan file: %w", err)
+			}
+			preamble = pf.Preamble
+		}
+
+		// Keep the file sorted and spaced after hand edits
+		changes, err := store.FormatFile(filePath, preamble)
+		if err != nil {
+			return fmt.Errorf("failed to format plan file: %w", err)
+		}
+		if len(changes) > 0 {
+			fmt.Printf("%s %s\n", output.Bold("Formatted:"), output.Success(strings.Join(changes, ", ")))
+			// Only a rewritten file is reported to post-format hooks, as with plan format
+			hookConfig.Trigger(hooks.PostFormat, hooks.Context{File: store.StoredPath(filePath), Date: dateutil.FormatMonth(scope.date)})
+		}
+	}
+
+	// Drop the header again if the day was left empty (only headers this edit added)
+	if !scope.month && !scope.noCreate && len(beforeLines) == 0 && config.GetDropEmptyDay(configFlag) {
+		removed, err := store.RemoveDateIfEmpty(scope.date)
+		if err != nil {
+			return fmt.Errorf("failed to remove empty date: %w", err)
+		}
+		if removed {
+			fmt.Println(output.Info(fmt.Sprintf("Removed empty section for %s", dateStr)))
+			return nil
+		}
+	}
+
+	if afterHash == beforeHash {
+		fmt.Println(output.Info(fmt.Sprintf("No changes to %s", dateStr)))
+		// The month file or date header may still be new
+		if afterHash != initialHash {
+			commitChanges(configFlag, store, filePath, commitMessage(dateStr, 0, 0))
+		}
+		return nil
+	}
+
+	afterLines, err := scope.lines(store, filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read date section: %w", err)
+	}
+	added, removed := planfile.DiffLines(beforeLines, afterLines)
+	fmt.Println(output.Success(planfile.FormatChangeSummary(dateStr, added, removed)))
+
+	commitChanges(configFlag, store, filePath, commitMessage(dateStr, added, removed))
 	return nil
 }
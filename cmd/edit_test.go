@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/abyss/plan-journal-cli/pkg/hooks"
+)
+
+func TestEditPreEditHookVeto(t *testing.T) {
+	t.Setenv("PLAN_CONFIG", filepath.Join(t.TempDir(), "nonexistent.config"))
+	hookConfig := hooks.Config{Commands: map[hooks.Event]string{hooks.PreEdit: "exit 1"}}
+
+	// A vetoed edit leaves the plans directory as it was, here not even created
+	plansDir := filepath.Join(t.TempDir(), "plans")
+	err := runEdit("", plansDir, "true %file%", "terminal", "", "2026-02-13", hookConfig, editOptions{})
+	if err == nil || !strings.Contains(err.Error(), "aborted by hook") {
+		t.Fatalf("runEdit() error = %v, want aborted by hook", err)
+	}
+	if _, err := os.Stat(plansDir); !os.IsNotExist(err) {
+		t.Errorf("plans directory was created, Stat() error = %v", err)
+	}
+
+	// The same for an existing month without the day
+	if err := os.MkdirAll(plansDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "# 2026-02\n\n## 2026-02-14\n* Later\n"
+	filePath := filepath.Join(plansDir, "2026-02.plan")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runEdit("", plansDir, "true %file%", "terminal", "", "2026-02-13", hookConfig, editOptions{}); err == nil {
+		t.Fatal("runEdit() should be aborted by the hook")
+	}
+	if data, _ := os.ReadFile(filePath); string(data) != content {
+		t.Errorf("vetoed edit changed the file:\n%s", data)
+	}
+	if entries, _ := os.ReadDir(plansDir); len(entries) != 1 {
+		t.Errorf("vetoed edit added files: %v", entries)
+	}
+}
+
+func TestEditPostFormatHook(t *testing.T) {
+	t.Setenv("PLAN_CONFIG", filepath.Join(t.TempDir(), "nonexistent.config"))
+	logFile := filepath.Join(t.TempDir(), "hooks.log")
+	hookConfig := hooks.Config{Commands: map[hooks.Event]string{
+		hooks.PostFormat: `echo "$PLAN_HOOK_EVENT $PLAN_HOOK_DATE" >> ` + logFile,
+	}}
+
+	// The "editor" adds an earlier day at the end, which the post-edit format moves up
+	plansDir := t.TempDir()
+	editorScript := filepath.Join(t.TempDir(), "editor.sh")
+	script := "#!/bin/sh\nprintf '\\n## 2026-02-01\\n* Early\\n' >> \"$1\"\n"
+	if err := os.WriteFile(editorScript, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := runEdit("", plansDir, editorScript+" %file%", "terminal", "", "2026-02-13", hookConfig, editOptions{}); err != nil {
+		t.Fatalf("runEdit() error = %v", err)
+	}
+
+	if got, _ := os.ReadFile(logFile); string(got) != "post-format 2026-02\n" {
+		t.Errorf("hooks ran: %q, want post-format 2026-02", got)
+	}
+}
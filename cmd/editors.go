@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/abyss/plan-journal-cli/pkg/config"
+	"github.com/abyss/plan-journal-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// NewEditorsCmd creates the editors command
+func NewEditorsCmd(configFlag, editorFlag *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "editors",
+		Short: "List known editors",
+		Long: `List built-in and user-registered editors with their command templates.
+
+Editors installed on PATH are marked, and the currently configured editor is highlighted.
+Register your own editors in the config file:
+  PLAN_CUSTOM_EDITOR_MYVIM=vim -u ~/.vimrc.plan +%line% %file%
+  PLAN_CUSTOM_EDITOR_MYVIM_TYPE=terminal`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEditors(*configFlag, *editorFlag)
+		},
+	}
+}
+
+func runEditors(configFlag, editorFlag string) error {
+	editors := config.GetEditors(configFlag)
+	currentCmd, err := config.GetEditorCommand(configFlag, editorFlag)
+	if err != nil {
+		return fmt.Errorf("failed to resolve editor: %w", err)
+	}
+
+	// Compute column width for names
+	names := config.EditorNames(editors)
+	nameWidth := len("NAME")
+	for _, name := range names {
+		nameWidth = max(nameWidth, len(name))
+	}
+
+	fmt.Printf("  %-*s  %-8s  %-9s  %s\n", nameWidth, "NAME", "TYPE", "INSTALLED", "COMMAND")
+	for _, name := range names {
+		tmpl := editors[name]
+
+		indicator := "  "
+		if tmpl.Command == currentCmd {
+			indicator = output.DateGreen("> ")
+		}
+
+		editorType := "gui"
+		if tmpl.Terminal {
+			editorType = "terminal"
+		}
+
+		// Pad before coloring so escape codes don't break alignment
+		installed := fmt.Sprintf("%-9s", "no")
+		if tmpl.Installed() {
+			installed = output.Success(fmt.Sprintf("%-9s", "yes"))
+		}
+
+		label := ""
+		if tmpl.Custom {
+			label = " " + output.Info("[custom]")
+		}
+
+		fmt.Printf("%s%-*s  %-8s  %s  %s%s\n", indicator, nameWidth, name, editorType, installed, tmpl.Command, label)
+	}
+
+	return nil
+}
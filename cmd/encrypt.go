@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/abyss/plan-journal-cli/pkg/config"
+	"github.com/abyss/plan-journal-cli/pkg/git"
+	"github.com/abyss/plan-journal-cli/pkg/output"
+	"github.com/abyss/plan-journal-cli/pkg/planfile"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// NewEncryptCmd creates the encrypt command
+func NewEncryptCmd(configFlag, locationFlag *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt the plans directory with a passphrase",
+		Long: `Encrypt every plan file in the plans directory with a passphrase.
+
+Files are stored as YYYY-MM.plan.enc, encrypted with AES-256-GCM under a key derived
+from the passphrase with scrypt. A .plan-key file is added to check the passphrase.
+Commands decrypt in memory; edit decrypts to a private temporary file while the
+editor is open and removes it afterwards.
+
+The passphrase is read from PLAN_PASSPHRASE, the output of PLAN_PASSPHRASE_COMMAND,
+or asked for in the terminal. There is no way to recover the files without it.
+
+Plain files are overwritten before they are removed, but SSDs and copy-on-write
+filesystems may keep old copies, and anything already committed to git stays in
+its history.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEncrypt(*configFlag, *locationFlag)
+		},
+	}
+}
+
+// NewDecryptCmd creates the decrypt command
+func NewDecryptCmd(configFlag, locationFlag *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "decrypt",
+		Short: "Turn an encrypted plans directory back into plain files",
+		Long: `Decrypt every YYYY-MM.plan.enc file in the plans directory back to YYYY-MM.plan
+and remove the .plan-key file.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDecrypt(*configFlag, *locationFlag)
+		},
+	}
+}
+
+func runEncrypt(configFlag, locationFlag string) error {
+	plansDir := config.GetPlansDirectory(configFlag, locationFlag)
+	store := &planfile.Store{Dir: plansDir}
+	if _, err := os.Stat(plansDir); err != nil {
+		return fmt.Errorf("plans directory not found: %s", plansDir)
+	}
+
+	var passphrase string
+	var err error
+	if store.Encrypted() {
+		// Finish an interrupted migration with the existing passphrase
+		passphrase, err = PassphraseSource(configFlag, true)()
+	} else {
+		passphrase, err = newPassphrase(configFlag)
+	}
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := store.EncryptDirectory(passphrase)
+	for _, name := range encrypted {
+		fmt.Printf("  %s %s\n", output.Success("Encrypted"), output.FilePath(name))
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(output.Success(fmt.Sprintf("Encrypted %s in %s", plural(len(encrypted), "file"), plansDir)))
+	if git.IsRepo(plansDir) {
+		fmt.Println(output.Warning("Earlier commits still contain the plain files; rewrite the git history to remove them."))
+	}
+	return nil
+}
+
+func runDecrypt(configFlag, locationFlag string) error {
+	plansDir := config.GetPlansDirectory(configFlag, locationFlag)
+	store := &planfile.Store{Dir: plansDir}
+	if !store.Encrypted() {
+		return fmt.Errorf("plans directory is not encrypted: %s", plansDir)
+	}
+
+	passphrase, err := PassphraseSource(configFlag, true)()
+	if err != nil {
+		return err
+	}
+
+	decrypted, err := store.DecryptDirectory(passphrase)
+	for _, name := range decrypted {
+		fmt.Printf("  %s %s\n", output.Success("Decrypted"), output.FilePath(name))
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(output.Success(fmt.Sprintf("Decrypted %s in %s", plural(len(decrypted), "file"), plansDir)))
+	return nil
+}
+
+// askPassphrase gets the passphrase for an encrypted journal; see ConfigureEncryption
+var askPassphrase func() (string, error)
+
+// ConfigureEncryption sets how commands get the passphrase for an encrypted journal
+// The source is asked at most once, however many stores the command opens
+func ConfigureEncryption(source func() (string, error)) {
+	askPassphrase = nil
+	if source != nil {
+		askPassphrase = sync.OnceValues(source)
+	}
+}
+
+// PassphraseSource returns a function that gets the passphrase for an encrypted journal
+// Priority: PLAN_PASSPHRASE env > PLAN_PASSPHRASE_COMMAND > terminal prompt (if interactive)
+func PassphraseSource(configFlag string, interactive bool) func() (string, error) {
+	return func() (string, error) {
+		// Priority 1: Environment variable
+		if passphrase := config.GetPassphrase(); passphrase != "" {
+			return passphrase, nil
+		}
+
+		// Priority 2: Passphrase command (e.g. a password manager)
+		if command := config.GetPassphraseCommand(configFlag); command != "" {
+			return runPassphraseCommand(command)
+		}
+
+		// Priority 3: Ask in the terminal
+		if interactive && term.IsTerminal(int(os.Stdin.Fd())) {
+			return readPassphrase("Journal passphrase: ")
+		}
+		return "", planfile.ErrNoPassphrase
+	}
+}
+
+// newPassphrase gets the passphrase for a journal being encrypted, asking twice in the terminal
+func newPassphrase(configFlag string) (string, error) {
+	if config.GetPassphrase() != "" || config.GetPassphraseCommand(configFlag) != "" {
+		return PassphraseSource(configFlag, false)()
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", planfile.ErrNoPassphrase
+	}
+
+	passphrase, err := readPassphrase("New passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase must not be empty")
+	}
+	confirm, err := readPassphrase("Repeat passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase != confirm {
+		return "", fmt.Errorf("passphrases don't match")
+	}
+	return passphrase, nil
+}
+
+// readPassphrase asks for a passphrase without echoing it
+// The prompt goes to stderr so it isn't mixed into piped output
+func readPassphrase(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return string(passphrase), nil
+}
+
+// runPassphraseCommand runs a command through the shell and returns its first line of output
+func runPassphraseCommand(command string) (string, error) {
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.Command("cmd", "/C", command)
+	} else {
+		c = exec.Command("sh", "-c", command)
+	}
+	c.Stdin = os.Stdin
+	c.Stderr = os.Stderr
+
+	var stdout bytes.Buffer
+	c.Stdout = &stdout
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("passphrase command failed: %w", err)
+	}
+
+	passphrase, _, _ := strings.Cut(stdout.String(), "\n")
+	passphrase = strings.TrimSuffix(passphrase, "\r")
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase command printed nothing")
+	}
+	return passphrase, nil
+}
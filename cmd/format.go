@@ -2,49 +2,49 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
-	"github.com/abyss/plan-journal-cli/pkg/config"
+	"github.com/abyss/plan-journal-cli/pkg/hooks"
 	"github.com/abyss/plan-journal-cli/pkg/output"
-	"github.com/abyss/plan-journal-cli/pkg/planfile"
 	"github.com/spf13/cobra"
 )
 
 // NewFormatCmd creates the format command
-func NewFormatCmd(configFlag, locationFlag, preambleFlag *string) *cobra.Command {
+func NewFormatCmd(configFlag, locationFlag, preambleFlag *string, hookConfig *hooks.Config) *cobra.Command {
 	return &cobra.Command{
-		Use:     "format <target>",
-		Aliases: []string{"fmt", "fix"},
-		Short:   "Format plan file",
-		Long:    "Format plan files by reordering date sections chronologically and updating/adding preamble. Target can be a date (YYYY-MM, YYYY-MM-DD, today, etc.), a file path, or a filename in the plans directory",
-		Args:    cobra.ExactArgs(1),
+		Use:               "format <target>",
+		Aliases:           []string{"fmt", "fix"},
+		Short:             "Format plan file",
+		Long:              "Format plan files by reordering date sections chronologically and updating/adding preamble. Target can be a date (YYYY-MM, YYYY-MM-DD, today, etc.), a file path, or a filename in the plans directory",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDateArg(configFlag, locationFlag, true),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runFormat(*configFlag, *locationFlag, *preambleFlag, args[0])
+			return runFormat(*configFlag, *locationFlag, *preambleFlag, args[0], *hookConfig)
 		},
 	}
 }
 
-func runFormat(configFlag, locationFlag, preambleFlag, target string) error {
+func runFormat(configFlag, locationFlag, preambleFlag, target string, hookConfig hooks.Config) error {
 	// Resolve configuration
-	plansDir := config.GetPlansDirectory(configFlag, locationFlag)
-	preamble := config.GetPreamble(configFlag, preambleFlag)
+	j, err := openJournalWithHooks(configFlag, locationFlag, preambleFlag, hookConfig)
+	if err != nil {
+		return err
+	}
 
 	// Format plan file
-	result, err := planfile.FormatPlanFile(target, plansDir, preamble)
+	result, err := j.Format(target)
 	if err != nil {
 		return fmt.Errorf("failed to format plan file: %w", err)
 	}
 
 	// Display result with color
-	if strings.HasPrefix(result, "No changes") {
-		fmt.Println(output.Info(result))
-	} else {
-		// Format "Changes: ..." messages in green
-		if changes, found := strings.CutPrefix(result, "Changes: "); found {
-			fmt.Printf("%s %s\n", output.Bold("Changes:"), output.Success(changes))
-		} else {
-			fmt.Println(output.Success(result))
-		}
+	if len(result.Changes) == 0 {
+		fmt.Println(output.Info("No changes needed"))
+		return nil
 	}
+	fmt.Printf("%s %s\n", output.Bold("Changes:"), output.Success(strings.Join(result.Changes, ", ")))
+
+	commitChanges(configFlag, j.Store(), result.File, "plan: format "+strings.TrimSuffix(filepath.Base(result.File), ".plan"))
 	return nil
 }
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/config"
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+	"github.com/abyss/plan-journal-cli/pkg/output"
+	"github.com/abyss/plan-journal-cli/pkg/planfile"
+	"github.com/spf13/cobra"
+)
+
+// heatmapCell is the character used for a day when colors are enabled
+const heatmapCell = "■"
+
+// heatmapASCII are the density characters used without color, from no activity to the most
+var heatmapASCII = []string{".", "-", "+", "*", "#"}
+
+// NewHeatmapCmd creates the heatmap command
+func NewHeatmapCmd(configFlag, locationFlag *string) *cobra.Command {
+	var by string
+	var weekStartFlag string
+
+	heatmapCmd := &cobra.Command{
+		Use:   "heatmap [YYYY]",
+		Short: "Show a year heatmap of how much you wrote each day",
+		Long: `Show a GitHub-style heatmap of a year, one column per week, with each day shaded
+by how much was written (lines or words). Without color, ASCII density characters are used.
+
+Examples:
+  plan heatmap               # The current year, by lines
+  plan heatmap 2025 --by words`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeFilterArg(configFlag, locationFlag, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			year := strconv.Itoa(time.Now().Year())
+			if len(args) > 0 {
+				year = args[0]
+			}
+			return runHeatmap(*configFlag, *locationFlag, weekStartFlag, year, by)
+		},
+	}
+
+	heatmapCmd.Flags().StringVar(&by, "by", "lines", "Shade by: lines or words")
+	heatmapCmd.Flags().StringVar(&weekStartFlag, "week-start", "", "First day of the week (default: monday)")
+	_ = heatmapCmd.RegisterFlagCompletionFunc("by", cobra.FixedCompletions([]cobra.Completion{"lines", "words"}, cobra.ShellCompDirectiveNoFileComp))
+	_ = heatmapCmd.RegisterFlagCompletionFunc("week-start", cobra.FixedCompletions(weekdayNames, cobra.ShellCompDirectiveNoFileComp))
+	return heatmapCmd
+}
+
+func runHeatmap(configFlag, locationFlag, weekStartFlag, year, by string) error {
+	// Resolve configuration
+	j, err := openJournal(configFlag, locationFlag, "")
+	if err != nil {
+		return err
+	}
+	weekStart, err := config.GetWeekStart(configFlag, weekStartFlag)
+	if err != nil {
+		return err
+	}
+	if by != "lines" && by != "words" {
+		return fmt.Errorf("invalid --by value: %s (expected lines or words)", by)
+	}
+	if len(year) != 4 {
+		return fmt.Errorf("invalid year format: %s (expected YYYY)", year)
+	}
+	start, end, err := dateutil.FilterRange(year)
+	if err != nil {
+		return err
+	}
+
+	days, err := j.Summaries(year)
+	if err != nil {
+		return fmt.Errorf("failed to discover dates: %w", err)
+	}
+
+	values := make(map[string]int, len(days))
+	maxValue, total, active := 0, 0, 0
+	for _, day := range days {
+		value := day.Lines
+		if by == "words" {
+			value = day.Words
+		}
+		values[day.Date] += value
+		maxValue = max(maxValue, values[day.Date])
+		total += value
+		if value > 0 {
+			active++
+		}
+	}
+
+	// Columns are weeks; the first one starts on the week containing January 1st
+	gridStart := start.AddDate(0, 0, -((int(start.Weekday()) - int(weekStart) + 7) % 7))
+	weeks := int(end.Sub(gridStart).Hours()/24)/7 + 1
+	today := dateutil.FormatDate(time.Now())
+
+	fmt.Printf("%s\n\n", output.Header(fmt.Sprintf("%s: %s on %s", year, plural(total, strings.TrimSuffix(by, "s")), plural(active, "day"))))
+	fmt.Println("     " + heatmapMonthLabels(gridStart, weeks, start.Year()))
+
+	for row := 0; row < 7; row++ {
+		weekday := time.Weekday((int(weekStart) + row) % 7)
+		label := "   "
+		if row%2 == 1 {
+			label = weekday.String()[:3]
+		}
+
+		var line strings.Builder
+		for week := 0; week < weeks; week++ {
+			date := gridStart.AddDate(0, 0, week*7+row)
+			dateStr := dateutil.FormatDate(date)
+			if date.Before(start) || date.After(end) || dateStr > today {
+				line.WriteString("  ")
+				continue
+			}
+			line.WriteString(heatmapShade(planfile.HeatLevel(values[dateStr], maxValue)) + " ")
+		}
+		fmt.Printf("%s  %s\n", output.Info(label), strings.TrimRight(line.String(), " "))
+	}
+
+	fmt.Println()
+	legend := make([]string, planfile.HeatLevels)
+	for level := range legend {
+		legend[level] = heatmapShade(level)
+	}
+	fmt.Printf("     Less %s More\n", strings.Join(legend, " "))
+	return nil
+}
+
+// heatmapShade renders one day at a shade level, in color or as an ASCII density character
+func heatmapShade(level int) string {
+	if output.ColorsDisabled() {
+		return heatmapASCII[level]
+	}
+	return output.HeatLevels[level](heatmapCell)
+}
+
+// heatmapMonthLabels returns the month names positioned over the week columns they start in
+func heatmapMonthLabels(gridStart time.Time, weeks, year int) string {
+	labels := []byte(strings.Repeat(" ", weeks*2+2))
+	next := 0 // First column free for the next label
+	for week := 0; week < weeks; week++ {
+		// Label the column holding the 1st of a month
+		for day := 0; day < 7; day++ {
+			date := gridStart.AddDate(0, 0, week*7+day)
+			if date.Day() != 1 || date.Year() != year {
+				continue
+			}
+			col := week * 2
+			if col >= next {
+				copy(labels[col:], date.Format("Jan"))
+				next = col + 4
+			}
+		}
+	}
+	return strings.TrimRight(string(labels), " ")
+}
@@ -2,18 +2,40 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
-	"github.com/abyss/plan-journal-cli/pkg/config"
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
 	"github.com/abyss/plan-journal-cli/pkg/output"
+	"github.com/abyss/plan-journal-cli/pkg/pager"
 	"github.com/abyss/plan-journal-cli/pkg/planfile"
 	"github.com/spf13/cobra"
 )
 
+// listColumns are the optional columns for plan list, in display order
+var listColumns = []string{"weekday", "title", "lines", "words", "tasks"}
+
+// listSortKeys are the accepted --sort values
+var listSortKeys = []string{"date", "lines", "words", "open", "done"}
+
+// listOptions holds the flags for runList
+type listOptions struct {
+	long    bool
+	columns []string
+	sortBy  string
+	reverse bool
+	limit   int
+	missing bool
+}
+
 // NewListCmd creates the list command
 func NewListCmd(configFlag, locationFlag *string) *cobra.Command {
-	return &cobra.Command{
+	var opts listOptions
+
+	listCmd := &cobra.Command{
 		Use:     "list [filter]",
 		Aliases: []string{"ls"},
 		Short:   "List all available dates in plan files",
@@ -23,84 +45,258 @@ The optional filter argument allows you to show only dates from a specific year
   - YYYY: Show all dates from that year (e.g., 2026)
   - YYYY-MM: Show all dates from that month (e.g., 2026-02)
 
+Columns (--columns or -l for all): weekday, title, lines, words, tasks
+
 Examples:
   plan list              # Show all dates
   plan list 2026         # Show dates from 2026
-  plan list 2026-02      # Show dates from February 2026`,
-		Args: cobra.MaximumNArgs(1),
+  plan list 2026-02      # Show dates from February 2026
+  plan list -l           # Show all columns
+  plan list --columns title,tasks
+  plan list --sort words --limit 10
+  plan list 2026-02 --missing   # Weekdays without an entry`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeFilterArg(configFlag, locationFlag, true),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			filter := ""
 			if len(args) > 0 {
 				filter = args[0]
 			}
-			return runList(*configFlag, *locationFlag, filter)
+			return runList(*configFlag, *locationFlag, filter, opts)
 		},
 	}
+
+	listCmd.Flags().BoolVarP(&opts.long, "long", "l", false, "Show all columns")
+	listCmd.Flags().StringSliceVar(&opts.columns, "columns", nil, "Columns to show: "+strings.Join(listColumns, ", "))
+	listCmd.Flags().StringVar(&opts.sortBy, "sort", "date", "Sort by: "+strings.Join(listSortKeys, ", "))
+	listCmd.Flags().BoolVarP(&opts.reverse, "reverse", "r", false, "Reverse the sort order")
+	listCmd.Flags().IntVarP(&opts.limit, "limit", "n", 0, "Show at most N dates")
+	listCmd.Flags().BoolVar(&opts.missing, "missing", false, "Show weekdays in the range that have no entry")
+	_ = listCmd.RegisterFlagCompletionFunc("columns", completeCommaList(listColumns))
+	_ = listCmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(listSortKeys, cobra.ShellCompDirectiveNoFileComp))
+	return listCmd
 }
 
-func runList(configFlag, locationFlag, filter string) error {
+func runList(configFlag, locationFlag, filter string, opts listOptions) error {
 	// Resolve configuration
-	plansDir := config.GetPlansDirectory(configFlag, locationFlag)
+	j, err := openJournal(configFlag, locationFlag, "")
+	if err != nil {
+		return err
+	}
+
+	columns, err := resolveListColumns(opts)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(listSortKeys, opts.sortBy) {
+		return fmt.Errorf("invalid sort key: %s (expected one of: %s)", opts.sortBy, strings.Join(listSortKeys, ", "))
+	}
 
 	// Discover dates
-	datesByMonth, err := planfile.DiscoverDates(plansDir, filter)
+	days, err := j.Summaries(filter)
 	if err != nil {
 		return fmt.Errorf("failed to discover dates: %w", err)
 	}
 
+	today := time.Now()
+	if opts.missing {
+		days = missingDays(days, filter, today)
+		columns = []string{"weekday"}
+	}
+
 	// Check if any dates were found
-	if len(datesByMonth) == 0 {
-		if filter != "" {
+	if len(days) == 0 {
+		switch {
+		case opts.missing:
+			fmt.Println(output.Info("No missing weekdays"))
+		case filter != "":
 			fmt.Println(output.Info(fmt.Sprintf("No dates found for %s", filter)))
-		} else {
+		default:
 			fmt.Println(output.Info("No dates found"))
 		}
 		return nil
 	}
 
-	// Sort months chronologically
-	months := make([]string, 0, len(datesByMonth))
-	for month := range datesByMonth {
-		months = append(months, month)
+	sortDays(days, opts.sortBy, opts.reverse)
+	if opts.limit > 0 && len(days) > opts.limit {
+		days = days[:opts.limit]
 	}
-	sort.Strings(months)
 
-	// Display dates grouped by month
-	today := time.Now()
+	var out strings.Builder
+	printDays(&out, days, columns, opts.sortBy == "date", today)
+	pager.Print(out.String())
+	return nil
+}
+
+// resolveListColumns validates --columns, or returns every column for --long
+func resolveListColumns(opts listOptions) ([]string, error) {
+	if opts.long {
+		return listColumns, nil
+	}
+
+	var columns []string
+	for _, column := range opts.columns {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !slices.Contains(listColumns, column) {
+			return nil, fmt.Errorf("invalid column: %s (expected one of: %s)", column, strings.Join(listColumns, ", "))
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// missingDays returns empty summaries for weekdays without an entry, up to today
+// Without a filter the range starts at the first entry
+func missingDays(days []planfile.DaySummary, filter string, today time.Time) []planfile.DaySummary {
+	var from, to time.Time
+	if filter != "" {
+		// The filter was validated by DiscoverDays
+		from, to, _ = dateutil.FilterRange(filter)
+	} else if len(days) > 0 {
+		from, _ = time.Parse("2006-01-02", days[0].Date)
+		to = today
+	} else {
+		return nil
+	}
+
+	// Days that haven't happened yet aren't missing
+	if todayDate, _ := time.Parse("2006-01-02", dateutil.FormatDate(today)); to.After(todayDate) {
+		to = todayDate
+	}
+
+	var missing []planfile.DaySummary
+	for _, date := range planfile.MissingWeekdays(days, from, to) {
+		missing = append(missing, planfile.DaySummary{Date: date})
+	}
+	return missing
+}
+
+// sortDays orders days by a sort key; dates go oldest first, counts largest first
+func sortDays(days []planfile.DaySummary, sortBy string, reverse bool) {
+	key := func(day planfile.DaySummary) int {
+		switch sortBy {
+		case "lines":
+			return day.Lines
+		case "words":
+			return day.Words
+		case "open":
+			return day.OpenTasks
+		case "done":
+			return day.DoneTasks
+		}
+		return 0
+	}
+
+	sort.SliceStable(days, func(i, j int) bool {
+		if sortBy == "date" {
+			return days[i].Date < days[j].Date
+		}
+		if ki, kj := key(days[i]), key(days[j]); ki != kj {
+			return ki > kj
+		}
+		return days[i].Date < days[j].Date
+	})
+
+	if reverse {
+		for i, j := 0, len(days)-1; i < j; i, j = i+1, j-1 {
+			days[i], days[j] = days[j], days[i]
+		}
+	}
+}
+
+// printDays writes one row per day with the selected columns aligned
+// Rows are grouped under month headers when listed by date
+func printDays(w io.Writer, days []planfile.DaySummary, columns []string, groupByMonth bool, today time.Time) {
 	todayStr := today.Format("2006-01-02")
-	yesterday := today.AddDate(0, 0, -1)
-	yesterdayStr := yesterday.Format("2006-01-02")
-	tomorrow := today.AddDate(0, 0, 1)
-	tomorrowStr := tomorrow.Format("2006-01-02")
-
-	for i, month := range months {
-		if i > 0 {
-			fmt.Println() // Blank line between months
+	yesterdayStr := today.AddDate(0, 0, -1).Format("2006-01-02")
+	tomorrowStr := today.AddDate(0, 0, 1).Format("2006-01-02")
+
+	// Build plain cells first so widths ignore color codes
+	headers := map[string]string{"weekday": "DAY", "title": "TITLE", "lines": "LINES", "words": "WORDS", "tasks": "TASKS"}
+	rows := make([][]string, len(days))
+	widths := make([]int, len(columns))
+	for i, column := range columns {
+		widths[i] = len(headers[column])
+	}
+	for r, day := range days {
+		rows[r] = make([]string, len(columns))
+		for i, column := range columns {
+			rows[r][i] = listCell(day, column)
+			widths[i] = max(widths[i], len([]rune(rows[r][i])))
 		}
-		fmt.Printf("%s:\n", output.Header(month))
-		for _, dateStr := range datesByMonth[month] {
-			// Add today indicator
-			indicator := "  "
-			if dateStr == todayStr {
-				indicator = output.DateGreen("> ")
-			} else {
-				indicator = "  "
-			}
+	}
 
-			// Add relative date label
-			var label string
-			if dateStr == todayStr {
-				label = " " + output.Highlight("[today]")
-			} else if dateStr == yesterdayStr {
-				label = " [yesterday]"
-			} else if dateStr == tomorrowStr {
-				label = " [tomorrow]"
+	if len(columns) > 0 {
+		header := "  " + fmt.Sprintf("%-10s", "DATE")
+		for i, column := range columns {
+			header += "  " + padCell(headers[column], widths[i], column)
+		}
+		fmt.Fprintln(w, output.Bold(strings.TrimRight(header, " ")))
+	}
+
+	currentMonth := ""
+	for r, day := range days {
+		if groupByMonth && day.Date[:7] != currentMonth {
+			if currentMonth != "" {
+				fmt.Fprintln(w) // Blank line between months
 			}
+			currentMonth = day.Date[:7]
+			fmt.Fprintf(w, "%s:\n", output.Header(currentMonth))
+		}
+
+		// Add today indicator
+		indicator := "  "
+		if day.Date == todayStr {
+			indicator = output.DateGreen("> ")
+		}
+
+		// Add relative date label
+		var label string
+		if day.Date == todayStr {
+			label = " " + output.Highlight("[today]")
+		} else if day.Date == yesterdayStr {
+			label = " [yesterday]"
+		} else if day.Date == tomorrowStr {
+			label = " [tomorrow]"
+		}
 
-			coloredDate := output.FormatDate(dateStr, today)
-			fmt.Printf("%s%s%s\n", indicator, coloredDate, label)
+		line := indicator + output.FormatDate(day.Date, today)
+		for i, column := range columns {
+			line += "  " + padCell(rows[r][i], widths[i], column)
 		}
+		if label == "" {
+			line = strings.TrimRight(line, " ")
+		}
+		fmt.Fprintln(w, line+label)
 	}
+}
 
-	return nil
+// listCell returns the plain text of a column for one day
+func listCell(day planfile.DaySummary, column string) string {
+	switch column {
+	case "weekday":
+		date, _ := time.Parse("2006-01-02", day.Date)
+		return date.Format("Mon")
+	case "title":
+		return day.Title
+	case "lines":
+		return fmt.Sprintf("%d", day.Lines)
+	case "words":
+		return fmt.Sprintf("%d", day.Words)
+	case "tasks":
+		if day.OpenTasks == 0 && day.DoneTasks == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%d open, %d done", day.OpenTasks, day.DoneTasks)
+	}
+	return ""
+}
+
+// padCell pads a cell to width; numbers are right-aligned
+func padCell(text string, width int, column string) string {
+	padding := strings.Repeat(" ", max(0, width-len([]rune(text))))
+	if column == "lines" || column == "words" {
+		return padding + text
+	}
+	return text + padding
 }
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// isInteractive reports whether stdin is a terminal the user can answer prompts on
+func isInteractive() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// promptYesNo asks a yes/no question on stdin, defaulting to no
+// Always answers no when stdin is not interactive
+func promptYesNo(question string) bool {
+	if !isInteractive() {
+		return false
+	}
+
+	fmt.Print(question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
@@ -1,60 +1,106 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"regexp"
-	"strings"
+	"os"
+	"time"
 
 	"github.com/abyss/plan-journal-cli/pkg/config"
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+	"github.com/abyss/plan-journal-cli/pkg/hooks"
+	"github.com/abyss/plan-journal-cli/pkg/journal"
 	"github.com/abyss/plan-journal-cli/pkg/output"
-	"github.com/abyss/plan-journal-cli/pkg/planfile"
+	"github.com/abyss/plan-journal-cli/pkg/pager"
+	"github.com/abyss/plan-journal-cli/pkg/render"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 // NewReadCmd creates the read command
 func NewReadCmd(configFlag, locationFlag *string) *cobra.Command {
-	return &cobra.Command{
+	var raw bool
+
+	readCmd := &cobra.Command{
 		Use:     "read <target>",
 		Aliases: []string{"view"},
 		Short:   "Read plan entries",
-		Long:    "Display plan entries for 'yesterday', 'today', 'tomorrow', a specific month (YYYY-MM), or a specific date (YYYY-MM-DD)",
-		Args:    cobra.ExactArgs(1),
+		Long: `Display plan entries for 'yesterday', 'today', 'tomorrow', a specific month (YYYY-MM), or a specific date (YYYY-MM-DD)
+
+Entries are rendered for the terminal: date headers are colored like plan list, Markdown
+bold, italic, code, and links are styled, task markers are colored by status, and long list
+items wrap under their text. Use --raw to print the file content exactly as written.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDateArg(configFlag, locationFlag, false),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runRead(*configFlag, *locationFlag, args[0])
+			return runRead(*configFlag, *locationFlag, args[0], raw)
 		},
 	}
+
+	readCmd.Flags().BoolVar(&raw, "raw", false, "Print entries as written, without rendering")
+	return readCmd
 }
 
-func runRead(configFlag, locationFlag, target string) error {
+func runRead(configFlag, locationFlag, target string, raw bool) error {
 	// Resolve configuration
-	plansDir := config.GetPlansDirectory(configFlag, locationFlag)
+	j, err := openJournal(configFlag, locationFlag, "")
+	if err != nil {
+		return err
+	}
 
-	// Read entries
-	content, err := planfile.ReadEntries(target, plansDir)
+	// Read entries: a whole month file, or one date section
+	date, err := dateutil.ParseTarget(target)
+	if err != nil {
+		return fmt.Errorf("failed to read entries: %w", err)
+	}
+	var content string
+	if dateutil.IsValidMonth(target) {
+		var month *journal.Month
+		if month, err = j.Month(date); err == nil {
+			content = month.Text
+		}
+	} else {
+		var day *journal.Day
+		if day, err = j.Day(date); err == nil {
+			content = day.Text()
+		}
+	}
 	if err != nil {
-		// If it's just "no entries found", print without usage
-		if strings.Contains(err.Error(), "no entries found") {
+		// Nothing written for the target isn't a failure; print it without usage
+		if errors.Is(err, journal.ErrNotFound) {
 			fmt.Println(output.Info(err.Error()))
 			return nil
 		}
 		return fmt.Errorf("failed to read entries: %w", err)
 	}
 
-	// Display content with colorized date headers
-	colorized := colorizePlanContent(content)
-	fmt.Println(colorized)
+	if raw {
+		pager.Print(content)
+		return nil
+	}
+
+	// Wrap to the terminal; piped output keeps its original line lengths
+	width := 0
+	if fd := int(os.Stdout.Fd()); term.IsTerminal(fd) {
+		if w, _, err := term.GetSize(fd); err == nil {
+			width = w
+		}
+	}
+	pager.Print(render.Markdown(content, render.Options{Width: width, Today: time.Now()}))
 	return nil
 }
 
-// colorizePlanContent adds color to date headers in plan content
-func colorizePlanContent(content string) string {
-	// Regex to match date headers like "## 2026-02-19"
-	dateHeaderRegex := regexp.MustCompile(`(?m)^## (\d{4}-\d{2}-\d{2})$`)
+// openJournal opens the plans directory resolved from the flags and config, without hooks
+// preambleFlag only matters to commands that create or format month files
+func openJournal(configFlag, locationFlag, preambleFlag string) (*journal.Journal, error) {
+	return openJournalWithHooks(configFlag, locationFlag, preambleFlag, hooks.Config{Disabled: true})
+}
 
-	// Replace date headers with colorized versions
-	return dateHeaderRegex.ReplaceAllStringFunc(content, func(match string) string {
-		// Extract just the date part
-		dateStr := strings.TrimPrefix(match, "## ")
-		return "## " + output.DateBlue(dateStr)
+// openJournalWithHooks opens the journal for a command that changes it, running hookConfig's hooks
+func openJournalWithHooks(configFlag, locationFlag, preambleFlag string, hookConfig hooks.Config) (*journal.Journal, error) {
+	return journal.Open(config.GetPlansDirectory(configFlag, locationFlag), journal.Options{
+		Preamble:      config.GetPreamble(configFlag, preambleFlag),
+		AskPassphrase: askPassphrase,
+		Hooks:         hookConfig,
 	})
 }
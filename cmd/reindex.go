@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/abyss/plan-journal-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// NewReindexCmd creates the reindex command
+func NewReindexCmd(configFlag, locationFlag *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reindex",
+		Short: "Rebuild the index of dates, titles, tags and counts",
+		Long: `Rebuild the index file (.plan-index) in the plans directory from every month file.
+
+The index lets list, cal, stats, heatmap, browse and completion skip month files that
+haven't changed since they were last read (by modification time and size). It is
+updated automatically; reindex is only needed if it seems out of date, for example
+after restoring files with their old timestamps.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReindex(*configFlag, *locationFlag)
+		},
+	}
+}
+
+func runReindex(configFlag, locationFlag string) error {
+	j, err := openJournal(configFlag, locationFlag, "")
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(j.Dir()); err != nil {
+		return fmt.Errorf("plans directory not found: %s", j.Dir())
+	}
+
+	files, days, err := j.Reindex()
+	if err != nil {
+		return fmt.Errorf("failed to rebuild index: %w", err)
+	}
+
+	fmt.Println(output.Success(fmt.Sprintf("Indexed %s in %s", plural(days, "day"), plural(files, "file"))))
+	return nil
+}
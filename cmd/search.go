@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/output"
+	"github.com/abyss/plan-journal-cli/pkg/pager"
+	"github.com/spf13/cobra"
+)
+
+// NewSearchCmd creates the search command
+func NewSearchCmd(configFlag, locationFlag *string) *cobra.Command {
+	return &cobra.Command{
+		Use:     "search <query>...",
+		Aliases: []string{"grep"},
+		Short:   "Find entries containing text",
+		Long: `Print the days whose header or entries contain the query, oldest first, with the
+matching lines. Matching ignores case, and the words are joined with spaces into one query.
+This is the same search as / in plan browse.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSearch(*configFlag, *locationFlag, strings.Join(args, " "))
+		},
+	}
+}
+
+func runSearch(configFlag, locationFlag, query string) error {
+	// Resolve configuration
+	j, err := openJournal(configFlag, locationFlag, "")
+	if err != nil {
+		return err
+	}
+
+	matches, err := j.Search(query)
+	if err != nil {
+		return fmt.Errorf("failed to search entries: %w", err)
+	}
+	if len(matches) == 0 {
+		fmt.Println(output.Info(fmt.Sprintf("No entries found for %q", query)))
+		return nil
+	}
+
+	today := time.Now()
+	var out strings.Builder
+	for i, match := range matches {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		heading := output.FormatDate(match.Date, today)
+		if match.Title != "" {
+			heading += " " + output.Bold(match.Title)
+		}
+		out.WriteString(heading + "\n")
+		for _, line := range match.Lines {
+			if !strings.HasPrefix(line, "## ") {
+				out.WriteString("  " + line + "\n")
+			}
+		}
+	}
+	out.WriteString("\n" + output.Info(fmt.Sprintf("%s matched", plural(len(matches), "day"))) + "\n")
+
+	pager.Print(out.String())
+	return nil
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/config"
+	"github.com/abyss/plan-journal-cli/pkg/hooks"
+	"github.com/abyss/plan-journal-cli/pkg/journal"
+	"github.com/abyss/plan-journal-cli/pkg/output"
+	"github.com/abyss/plan-journal-cli/pkg/server"
+	"github.com/spf13/cobra"
+)
+
+// NewServeCmd creates the serve command
+func NewServeCmd(configFlag, locationFlag, preambleFlag *string, hookConfig *hooks.Config) *cobra.Command {
+	var addrFlag, tokenFlag, readOnlyFlag, hostsFlag string
+
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the journal over HTTP with a JSON API and web UI",
+		Long: `Serve the plans directory over HTTP until interrupted, with a small web UI at / for
+reading, searching, and adding entries from a browser or phone, and a JSON API under /api/:
+
+  GET  /api/months               Months that have a plan file
+  GET  /api/days?filter=YYYY-MM  Days with titles, tags, and counts (filter is optional)
+  GET  /api/days/<date>          One day (YYYY-MM-DD, today, yesterday, or tomorrow)
+  POST /api/days/<date>          Append {"text": "..."} to a day, like plan add
+  GET  /api/search?q=<query>     Days containing the query, like plan search
+  POST /api/format/<YYYY-MM>     Format a month file, like plan format
+
+Changes take the same lock as the plan command, so both can be used at once. With a
+token, API requests must send "Authorization: Bearer <token>"; open the web UI once as
+http://<addr>/#token=<token> to save it in the browser. Set the token with
+PLAN_SERVE_TOKEN or the config file rather than --token to keep it out of the process
+list. An encrypted journal always needs a token: without one, a random token is made
+for the run and printed in the URL. The server listens on 127.0.0.1:8080 by default,
+which only this machine can reach.
+Requests must be addressed to localhost, an IP address, or a name listed with --hosts
+(or PLAN_SERVE_HOSTS), so other web sites can't reach the journal through your browser.`,
+		Example: `  plan serve
+  PLAN_SERVE_TOKEN=s3cret plan serve --addr 0.0.0.0:8080
+  plan serve --read-only
+  plan serve --addr 0.0.0.0:8080 --hosts laptop.local`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe(*configFlag, *locationFlag, *preambleFlag, *hookConfig, addrFlag, tokenFlag, readOnlyFlag, hostsFlag)
+		},
+	}
+
+	serveCmd.Flags().StringVar(&addrFlag, "addr", "", "Address to listen on (default: 127.0.0.1:8080)")
+	serveCmd.Flags().StringVar(&tokenFlag, "token", "", "Token API requests must send (default: none)")
+	serveCmd.Flags().StringVar(&readOnlyFlag, "read-only", "", "Reject changes to the journal (true/false, default: false)")
+	serveCmd.Flags().Lookup("read-only").NoOptDefVal = "true"
+	serveCmd.Flags().StringVar(&hostsFlag, "hosts", "", "Host names the server may be reached by, comma-separated (default: localhost and IP addresses)")
+	return serveCmd
+}
+
+func runServe(configFlag, locationFlag, preambleFlag string, hookConfig hooks.Config, addrFlag, tokenFlag, readOnlyFlag, hostsFlag string) error {
+	// Resolve configuration
+	plansDir := config.GetPlansDirectory(configFlag, locationFlag)
+	opts := journal.Options{Preamble: config.GetPreamble(configFlag, preambleFlag), Hooks: hookConfig}
+	addr := config.GetServeAddr(configFlag, addrFlag)
+	token := config.GetServeToken(configFlag, tokenFlag)
+	readOnly := config.GetServeReadOnly(configFlag, readOnlyFlag)
+	hosts := config.GetServeHosts(configFlag, hostsFlag)
+	// A name given as the listen address is always one the server is reached by
+	if host, _, err := net.SplitHostPort(addr); err == nil && host != "" {
+		hosts = append(hosts, host)
+	}
+
+	j, err := journal.Open(plansDir, opts)
+	if err != nil {
+		return err
+	}
+	// Unlock an encrypted journal now, rather than prompting in the middle of a request
+	if j.Encrypted() {
+		if opts.Passphrase, err = PassphraseSource(configFlag, true)(); err != nil {
+			return err
+		}
+		if j, err = journal.Open(plansDir, opts); err != nil {
+			return err
+		}
+	}
+
+	// Other users of this machine can reach a loopback address too, so an encrypted
+	// journal is never served decrypted without a token
+	generatedToken := token == "" && j.Encrypted()
+	if generatedToken {
+		token = rand.Text()
+	}
+
+	// Commits run one at a time, since git can't update its index concurrently
+	var commitMu sync.Mutex
+	handler := server.New(j, server.Options{
+		Token:    token,
+		ReadOnly: readOnly,
+		Hosts:    hosts,
+		Changed: func(c server.Change) {
+			commitMu.Lock()
+			defer commitMu.Unlock()
+			if c.Lines > 0 {
+				commitChanges(configFlag, j.Store(), c.File, commitMessage(c.Date, c.Lines, 0))
+			} else {
+				commitChanges(configFlag, j.Store(), c.File, "plan: format "+c.Date)
+			}
+		},
+	})
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	srv := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	mode, access := "", "read and change"
+	if readOnly {
+		mode, access = " (read-only)", "read"
+	}
+	url := serveURL(addr, listener.Addr())
+	if generatedToken {
+		url += "#token=" + token
+	}
+	fmt.Printf("%s %s at %s%s\n", output.Success("Serving"), j.Dir(), url, mode)
+	if generatedToken {
+		fmt.Println(output.Info("The journal is encrypted, so requests need the token in this URL; set PLAN_SERVE_TOKEN to choose your own"))
+	}
+	if token == "" && !isLoopback(listener.Addr()) {
+		fmt.Println(output.Warning(fmt.Sprintf("Warning: anyone on the network can %s the journal; set PLAN_SERVE_TOKEN to require a token", access)))
+	}
+	fmt.Println(output.Info("Press Ctrl+C to stop"))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	errs := make(chan error, 1)
+	go func() { errs <- srv.Serve(listener) }()
+
+	select {
+	case err := <-errs:
+		return fmt.Errorf("server stopped: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to stop server: %w", err)
+	}
+	return nil
+}
+
+// serveURL returns the URL of the server, with the host as configured (e.g. 0.0.0.0) and
+// the port it is listening on
+func serveURL(addr string, listening net.Addr) string {
+	host, _, _ := net.SplitHostPort(addr)
+	_, port, _ := net.SplitHostPort(listening.String())
+	if host == "" {
+		host = "0.0.0.0"
+	}
+	return "http://" + net.JoinHostPort(host, port) + "/"
+}
+
+// isLoopback reports whether an address only accepts connections from this machine
+func isLoopback(addr net.Addr) bool {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
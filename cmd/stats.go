@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/config"
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+	"github.com/abyss/plan-journal-cli/pkg/output"
+	"github.com/abyss/plan-journal-cli/pkg/pager"
+	"github.com/abyss/plan-journal-cli/pkg/planfile"
+	"github.com/spf13/cobra"
+)
+
+// statsBarWidth is the width of the longest bar in the weekday chart
+const statsBarWidth = 30
+
+// NewStatsCmd creates the stats command
+func NewStatsCmd(configFlag, locationFlag *string) *cobra.Command {
+	var jsonOutput bool
+
+	statsCmd := &cobra.Command{
+		Use:   "stats [filter]",
+		Short: "Show journaling statistics and streaks",
+		Long: `Show statistics computed from your plan files: streaks, entries per weekday,
+average words per day, busiest months, the share of workdays logged, and task completion.
+
+The optional filter limits the range to a year (YYYY) or month (YYYY-MM). Without a
+filter, the range runs from your first entry to today.
+
+Examples:
+  plan stats             # All time
+  plan stats 2026        # A single year
+  plan stats --json      # Machine-readable output`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeFilterArg(configFlag, locationFlag, true),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter := ""
+			if len(args) > 0 {
+				filter = args[0]
+			}
+			return runStats(*configFlag, *locationFlag, filter, jsonOutput)
+		},
+	}
+
+	statsCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output as JSON")
+	return statsCmd
+}
+
+// statsJSON is the JSON shape of plan stats
+type statsJSON struct {
+	planfile.Stats
+	EntriesPerWeekday map[string]int `json:"entries_per_weekday"`
+	WorkdayShare      float64        `json:"workday_share"`
+	TaskCompletion    float64        `json:"task_completion"`
+}
+
+func runStats(configFlag, locationFlag, filter string, jsonOutput bool) error {
+	// Resolve configuration
+	j, err := openJournal(configFlag, locationFlag, "")
+	if err != nil {
+		return err
+	}
+
+	days, err := j.Summaries(filter)
+	if err != nil {
+		return fmt.Errorf("failed to discover dates: %w", err)
+	}
+
+	today := time.Now()
+	from, to, ok := statsRange(days, filter, today)
+	var stats planfile.Stats
+	if ok {
+		stats = planfile.ComputeStats(days, from, to, today)
+	}
+	if stats.Entries == 0 && !jsonOutput {
+		if filter != "" {
+			fmt.Println(output.Info(fmt.Sprintf("No dates found for %s", filter)))
+		} else {
+			fmt.Println(output.Info("No dates found"))
+		}
+		return nil
+	}
+
+	if jsonOutput {
+		perWeekday := make(map[string]int, 7)
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			perWeekday[strings.ToLower(d.String())] = stats.EntriesPerWeekday[d]
+		}
+		if stats.BusiestMonths == nil {
+			stats.BusiestMonths = []planfile.MonthCount{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(statsJSON{
+			Stats:             stats,
+			EntriesPerWeekday: perWeekday,
+			WorkdayShare:      stats.WorkdayShare(),
+			TaskCompletion:    stats.TaskCompletion(),
+		})
+	}
+
+	weekStart, err := config.GetWeekStart(configFlag, "")
+	if err != nil {
+		weekStart = config.DefaultWeekStart
+	}
+	var out strings.Builder
+	printStats(&out, stats, filter, weekStart)
+	pager.Print(out.String())
+	return nil
+}
+
+// statsRange returns the range stats cover: the filter's range, or the first entry to today
+func statsRange(days []planfile.DaySummary, filter string, today time.Time) (time.Time, time.Time, bool) {
+	if filter != "" {
+		// The filter was validated by DiscoverDays
+		from, to, _ := dateutil.FilterRange(filter)
+		return from, to, true
+	}
+	if len(days) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	from, _ := time.Parse("2006-01-02", days[0].Date)
+	to, _ := time.Parse("2006-01-02", dateutil.FormatDate(today))
+	if last, _ := time.Parse("2006-01-02", days[len(days)-1].Date); last.After(to) {
+		to = last
+	}
+	return from, to, true
+}
+
+// printStats writes the human-readable report
+func printStats(w io.Writer, stats planfile.Stats, filter string, weekStart time.Weekday) {
+	title := "All time"
+	if filter != "" {
+		title = filter
+	}
+	fmt.Fprintf(w, "%s %s\n\n", output.Header("Statistics: "+title), output.Info(fmt.Sprintf("(%s to %s)", stats.From, stats.To)))
+
+	row := func(label, value string) {
+		fmt.Fprintf(w, "  %-18s %s\n", label+":", value)
+	}
+	row("Entries", output.Bold(plural(stats.Entries, "day")))
+	row("Current streak", output.Bold(plural(stats.CurrentStreak, "day")))
+	longest := plural(stats.LongestStreak, "day")
+	if stats.LongestStreak > 0 {
+		longest += output.Info(fmt.Sprintf(" (ended %s)", stats.LongestStreakEnd))
+	}
+	row("Longest streak", output.Bold(longest))
+	row("Average words", fmt.Sprintf("%s per day", output.Bold(fmt.Sprintf("%.1f", stats.AverageWords))))
+	row("Workdays logged", fmt.Sprintf("%s of %d (%s)",
+		output.Bold(fmt.Sprintf("%d", stats.WorkdaysLogged)), stats.Workdays, percent(stats.WorkdayShare())))
+	if tasks := stats.OpenTasks + stats.DoneTasks; tasks > 0 {
+		row("Tasks done", fmt.Sprintf("%s of %d (%s)",
+			output.Bold(fmt.Sprintf("%d", stats.DoneTasks)), tasks, percent(stats.TaskCompletion())))
+	} else {
+		row("Tasks done", output.Info("no tasks"))
+	}
+
+	// Weekday chart, starting on the configured first day of the week
+	fmt.Fprintf(w, "\n%s\n", output.Header("Entries per weekday"))
+	most := 0
+	for _, count := range stats.EntriesPerWeekday {
+		most = max(most, count)
+	}
+	for i := 0; i < 7; i++ {
+		day := time.Weekday((int(weekStart) + i) % 7)
+		count := stats.EntriesPerWeekday[day]
+		bar := ""
+		if most > 0 {
+			bar = strings.Repeat(barChar(), count*statsBarWidth/most)
+		}
+		fmt.Fprintf(w, "  %s  %4d  %s\n", day.String()[:3], count, output.Success(bar))
+	}
+
+	if len(stats.BusiestMonths) > 0 {
+		fmt.Fprintf(w, "\n%s\n", output.Header("Busiest months"))
+		for _, month := range stats.BusiestMonths {
+			fmt.Fprintf(w, "  %s  %-9s %s\n", month.Month, plural(month.Entries, "day"), output.Info(plural(month.Words, "word")))
+		}
+	}
+}
+
+// barChar is the block used for charts, falling back to ASCII without color
+func barChar() string {
+	if output.ColorsDisabled() {
+		return "#"
+	}
+	return "█"
+}
+
+// plural formats a count with a singular or plural noun
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// percent formats a fraction as a whole percentage
+func percent(fraction float64) string {
+	return fmt.Sprintf("%.0f%%", fraction*100)
+}
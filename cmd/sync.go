@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/abyss/plan-journal-cli/pkg/config"
+	"github.com/abyss/plan-journal-cli/pkg/git"
+	"github.com/abyss/plan-journal-cli/pkg/output"
+	"github.com/abyss/plan-journal-cli/pkg/planfile"
+	"github.com/spf13/cobra"
+)
+
+// NewSyncCmd creates the sync command
+func NewSyncCmd(configFlag, locationFlag *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "sync",
+		Short: "Pull and push the plans git repository",
+		Long: `Synchronize the plans directory with its git remote.
+
+Pulls with rebase from the configured remote (PLAN_GIT_REMOTE, default: origin) and
+branch (PLAN_GIT_BRANCH, default: the current branch), then pushes local commits.
+If the rebase hits conflicts, it is aborted and the conflicting files are listed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSync(*configFlag, *locationFlag)
+		},
+	}
+}
+
+func runSync(configFlag, locationFlag string) error {
+	// Resolve configuration
+	plansDir := config.GetPlansDirectory(configFlag, locationFlag)
+	remote := config.GetGitRemote(configFlag)
+
+	if !git.IsRepo(plansDir) {
+		return fmt.Errorf("plans directory is not a git repository: %s", plansDir)
+	}
+
+	branch := config.GetGitBranch(configFlag)
+	if branch == "" {
+		current, err := git.CurrentBranch(plansDir)
+		if err != nil {
+			return fmt.Errorf("failed to determine current branch: %w", err)
+		}
+		branch = current
+	}
+
+	err := git.Sync(plansDir, remote, branch)
+	var conflict *git.ConflictError
+	if errors.As(err, &conflict) {
+		fmt.Println(output.Error("Sync stopped: your changes conflict with the remote in:"))
+		for _, file := range conflict.Files {
+			fmt.Printf("  %s\n", output.FilePath(file))
+		}
+		fmt.Println(output.Info("The rebase was aborted and your local commits are untouched."))
+		fmt.Println(output.Info(fmt.Sprintf("Resolve manually with: git -C %s pull --rebase %s %s", plansDir, remote, branch)))
+		return fmt.Errorf("sync failed due to conflicts")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to sync: %w", err)
+	}
+
+	fmt.Printf("%s %s/%s\n", output.Success("Synced with"), remote, branch)
+	return nil
+}
+
+// commitChanges commits a changed plan file when git auto-commit is enabled
+// Failures are reported as warnings, the file change itself already succeeded
+func commitChanges(configFlag string, store *planfile.Store, filePath, message string) {
+	if !config.GetGitAutoCommit(configFlag) || !git.IsRepo(store.Dir) {
+		return
+	}
+
+	committed, err := git.CommitFiles(store.Dir, []string{store.StoredPath(filePath)}, message)
+	if err != nil {
+		fmt.Println(output.Warning(fmt.Sprintf("Failed to commit changes: %v", err)))
+		return
+	}
+	if committed {
+		fmt.Printf("%s %s\n", output.Info("Committed:"), message)
+	}
+}
+
+// commitMessage builds the auto-commit message for an edit, e.g. "plan: 2026-02-13 (+5 lines)"
+// An edit that only created the file or date header has no line counts
+func commitMessage(date string, added, removed int) string {
+	switch {
+	case removed > 0:
+		return fmt.Sprintf("plan: %s (+%d -%s)", date, added, plural(removed, "line"))
+	case added > 0:
+		return fmt.Sprintf("plan: %s (+%s)", date, plural(added, "line"))
+	default:
+		return "plan: " + date
+	}
+}
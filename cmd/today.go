@@ -1,17 +1,23 @@
 package cmd
 
 import (
+	"github.com/abyss/plan-journal-cli/pkg/hooks"
 	"github.com/spf13/cobra"
 )
 
 // NewTodayCmd creates the today command
-func NewTodayCmd(configFlag, locationFlag, editorFlag, editorTypeFlag, preambleFlag *string) *cobra.Command {
-	return &cobra.Command{
+func NewTodayCmd(configFlag, locationFlag, editorFlag, editorTypeFlag, preambleFlag *string, hookConfig *hooks.Config) *cobra.Command {
+	var opts editOptions
+
+	todayCmd := &cobra.Command{
 		Use:   "today",
 		Short: "Open today's plan file in editor",
 		Long:  "Opens the current month's plan file with cursor positioned at today's entry insertion point",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runEdit(*configFlag, *locationFlag, *editorFlag, *editorTypeFlag, *preambleFlag, "today")
+			return runEdit(*configFlag, *locationFlag, *editorFlag, *editorTypeFlag, *preambleFlag, "today", *hookConfig, opts)
 		},
 	}
+
+	todayCmd.Flags().BoolVar(&opts.noCreate, "no-create", false, "Don't create the month file or date header")
+	return todayCmd
 }
@@ -1,17 +1,23 @@
 package cmd
 
 import (
+	"github.com/abyss/plan-journal-cli/pkg/hooks"
 	"github.com/spf13/cobra"
 )
 
 // NewTomorrowCmd creates the tomorrow command
-func NewTomorrowCmd(configFlag, locationFlag, editorFlag, editorTypeFlag, preambleFlag *string) *cobra.Command {
-	return &cobra.Command{
+func NewTomorrowCmd(configFlag, locationFlag, editorFlag, editorTypeFlag, preambleFlag *string, hookConfig *hooks.Config) *cobra.Command {
+	var opts editOptions
+
+	tomorrowCmd := &cobra.Command{
 		Use:   "tomorrow",
 		Short: "Open tomorrow's plan file in editor",
 		Long:  "Opens the plan file for tomorrow with cursor positioned at tomorrow's entry insertion point",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runEdit(*configFlag, *locationFlag, *editorFlag, *editorTypeFlag, *preambleFlag, "tomorrow")
+			return runEdit(*configFlag, *locationFlag, *editorFlag, *editorTypeFlag, *preambleFlag, "tomorrow", *hookConfig, opts)
 		},
 	}
+
+	tomorrowCmd.Flags().BoolVar(&opts.noCreate, "no-create", false, "Don't create the month file or date header")
+	return tomorrowCmd
 }
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/abyss/plan-journal-cli/pkg/config"
+	"github.com/abyss/plan-journal-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// NewTrustCmd creates the trust command
+func NewTrustCmd(configFlag *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "trust",
+		Short: "Allow the project journal here to run commands",
+		Long: `Trust the project journal found by walking up from the working directory.
+
+A project's .plan.config (or .plans/.config) comes with the repository, so anyone who can
+change the repository could use it to run commands. Until the project is trusted, its
+config file can't set PLAN_EDITOR, PLAN_EDITOR_FALLBACK, PLAN_CUSTOM_EDITOR_*, PLAN_HOOK_*,
+PLAN_HOOKS_DIR, PLAN_PAGER, or PLAN_PASSPHRASE_COMMAND, and hooks in its .hooks directory
+don't run. Everything else in the file still applies.
+
+Trusting adds PLAN_TRUSTED_PROJECT=<project root> to your own config file (not the
+project's). Remove that line to stop trusting the project.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTrust(*configFlag)
+		},
+	}
+}
+
+func runTrust(configFlag string) error {
+	project := config.DiscoverProject()
+	if project == nil {
+		return fmt.Errorf("no project journal (%s or %s/) found in the working directory or above it", config.ProjectConfigName, config.ProjectPlansDirName)
+	}
+	if project.Trusted(configFlag) {
+		fmt.Printf("%s %s\n", output.Info("Already trusted:"), output.FilePath(project.Root))
+		return nil
+	}
+
+	if err := project.Trust(configFlag); err != nil {
+		return err
+	}
+	fmt.Printf("%s %s %s\n", output.Success("Trusted"), output.FilePath(project.Root), output.Info("in "+config.UserConfigPath(configFlag)))
+	return nil
+}
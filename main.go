@@ -6,7 +6,9 @@ import (
 
 	"github.com/abyss/plan-journal-cli/cmd"
 	"github.com/abyss/plan-journal-cli/pkg/config"
+	"github.com/abyss/plan-journal-cli/pkg/hooks"
 	"github.com/abyss/plan-journal-cli/pkg/output"
+	"github.com/abyss/plan-journal-cli/pkg/pager"
 	"github.com/spf13/cobra"
 )
 
@@ -17,38 +19,87 @@ var (
 	editorTypeFlag string
 	preambleFlag   string
 	noColorFlag    string
+	noHooksFlag    string
+	noPagerFlag    string
 )
 
 func main() {
+	// Hooks for the command being run, set up once the flags are parsed
+	var hookConfig hooks.Config
+
 	rootCmd := &cobra.Command{
 		Use:   "plan",
 		Short: "Plan Journal CLI - Manage daily plan files",
 		Long: `Plan Journal CLI helps you manage daily plan files organized by month.
 Files are structured with month headers and chronologically ordered date sections.`,
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRun: func(command *cobra.Command, args []string) {
 			// Initialize colors based on configuration
 			noColor := config.GetNoColor(configFlag, noColorFlag)
 			output.SetColorsDisabled(noColor)
+			if err := output.ApplyTheme(config.GetTheme(configFlag), config.GetColorOverrides(configFlag)); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+			pager.Configure(config.GetPager(configFlag, noPagerFlag))
+
+			// Encrypted journals ask for the passphrase on first use; completion must not prompt
+			cmd.ConfigureEncryption(cmd.PassphraseSource(configFlag, command.Name() != cobra.ShellCompRequestCmd))
+
+			// Lifecycle hooks for this command, passed to the commands that change the journal
+			plansDir := config.GetPlansDirectory(configFlag, locationFlag)
+			hookCommands := make(map[hooks.Event]string)
+			for event, command := range config.GetHookCommands(configFlag) {
+				hookCommands[hooks.Event(event)] = command
+			}
+			hookConfig = hooks.Config{
+				Dir:       config.GetHooksDirectory(configFlag, plansDir),
+				Commands:  hookCommands,
+				Timeout:   config.GetHookTimeout(configFlag),
+				Disabled:  config.GetNoHooks(configFlag, noHooksFlag),
+				Operation: command.Name(),
+				PlansDir:  plansDir,
+			}
 		},
 	}
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&configFlag, "config", "", "Override config file location (default: ~/plans/.config)")
 	rootCmd.PersistentFlags().StringVar(&locationFlag, "location", "", "Override plans directory (default: ~/plans/)")
-	rootCmd.PersistentFlags().StringVar(&editorFlag, "editor", "", "Override default editor (default: vim)")
+	rootCmd.PersistentFlags().StringVar(&editorFlag, "editor", "", "Override default editor (default: $VISUAL, $EDITOR, or vim)")
 	rootCmd.PersistentFlags().StringVar(&editorTypeFlag, "editor-type", "", "Override editor type: terminal, gui, or auto (default: auto)")
 	rootCmd.PersistentFlags().StringVar(&preambleFlag, "preamble", "", "Override preamble text (default: empty)")
 	rootCmd.PersistentFlags().StringVar(&noColorFlag, "no-color", "", "Disable color output (true/false, default: false)")
+	rootCmd.PersistentFlags().StringVar(&noHooksFlag, "no-hooks", "", "Skip lifecycle hooks (true/false, default: false)")
+	rootCmd.PersistentFlags().Lookup("no-hooks").NoOptDefVal = "true"
+	rootCmd.PersistentFlags().StringVar(&noPagerFlag, "no-pager", "", "Print long output directly instead of using the pager")
+	rootCmd.PersistentFlags().Lookup("no-pager").NoOptDefVal = "true"
+	cmd.RegisterGlobalFlagCompletions(rootCmd, &configFlag)
 
 	// Add commands
-	rootCmd.AddCommand(cmd.NewTodayCmd(&configFlag, &locationFlag, &editorFlag, &editorTypeFlag, &preambleFlag))
-	rootCmd.AddCommand(cmd.NewTomorrowCmd(&configFlag, &locationFlag, &editorFlag, &editorTypeFlag, &preambleFlag))
-	rootCmd.AddCommand(cmd.NewEditCmd(&configFlag, &locationFlag, &editorFlag, &editorTypeFlag, &preambleFlag))
+	rootCmd.AddCommand(cmd.NewTodayCmd(&configFlag, &locationFlag, &editorFlag, &editorTypeFlag, &preambleFlag, &hookConfig))
+	rootCmd.AddCommand(cmd.NewTomorrowCmd(&configFlag, &locationFlag, &editorFlag, &editorTypeFlag, &preambleFlag, &hookConfig))
+	rootCmd.AddCommand(cmd.NewEditCmd(&configFlag, &locationFlag, &editorFlag, &editorTypeFlag, &preambleFlag, &hookConfig))
+	rootCmd.AddCommand(cmd.NewAddCmd(&configFlag, &locationFlag, &preambleFlag, &hookConfig))
 	rootCmd.AddCommand(cmd.NewReadCmd(&configFlag, &locationFlag))
+	rootCmd.AddCommand(cmd.NewSearchCmd(&configFlag, &locationFlag))
+	rootCmd.AddCommand(cmd.NewBrowseCmd(&configFlag, &locationFlag, &editorFlag, &editorTypeFlag, &preambleFlag, &hookConfig))
 	rootCmd.AddCommand(cmd.NewListCmd(&configFlag, &locationFlag))
-	rootCmd.AddCommand(cmd.NewFormatCmd(&configFlag, &locationFlag, &preambleFlag))
+	rootCmd.AddCommand(cmd.NewCalCmd(&configFlag, &locationFlag))
+	rootCmd.AddCommand(cmd.NewStatsCmd(&configFlag, &locationFlag))
+	rootCmd.AddCommand(cmd.NewHeatmapCmd(&configFlag, &locationFlag))
+	rootCmd.AddCommand(cmd.NewFormatCmd(&configFlag, &locationFlag, &preambleFlag, &hookConfig))
+	rootCmd.AddCommand(cmd.NewReindexCmd(&configFlag, &locationFlag))
+	rootCmd.AddCommand(cmd.NewSyncCmd(&configFlag, &locationFlag))
+	rootCmd.AddCommand(cmd.NewServeCmd(&configFlag, &locationFlag, &preambleFlag, &hookConfig))
+	rootCmd.AddCommand(cmd.NewEncryptCmd(&configFlag, &locationFlag))
+	rootCmd.AddCommand(cmd.NewDecryptCmd(&configFlag, &locationFlag))
+	rootCmd.AddCommand(cmd.NewEditorsCmd(&configFlag, &editorFlag))
 	rootCmd.AddCommand(cmd.NewConfigCmd(&configFlag, &locationFlag, &editorFlag, &editorTypeFlag, &preambleFlag, &noColorFlag))
+	rootCmd.AddCommand(cmd.NewTrustCmd(&configFlag))
 	rootCmd.AddCommand(cmd.NewColorsCmd())
+	rootCmd.AddCommand(cmd.NewCompletionCmd())
+
+	// Replace cobra's default completion command with plan completion
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
 	// Execute
 	if err := rootCmd.Execute(); err != nil {
@@ -0,0 +1,91 @@
+package browse
+
+import "unicode/utf8"
+
+// KeyType identifies a decoded key press
+type KeyType int
+
+const (
+	KeyRune KeyType = iota // A printable character (see Key.Rune)
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyPgUp
+	KeyPgDn
+	KeyHome
+	KeyEnd
+	KeyEnter
+	KeyEsc
+	KeyBackspace
+	KeyCtrlC
+)
+
+// Key is a single key press
+type Key struct {
+	Type KeyType
+	Rune rune
+}
+
+// escapeSequences maps terminal escape sequences (without the leading ESC) to keys
+var escapeSequences = map[string]KeyType{
+	"[A": KeyUp, "[B": KeyDown, "[C": KeyRight, "[D": KeyLeft,
+	"OA": KeyUp, "OB": KeyDown, "OC": KeyRight, "OD": KeyLeft,
+	"[5~": KeyPgUp, "[6~": KeyPgDn,
+	"[H": KeyHome, "[F": KeyEnd, "OH": KeyHome, "OF": KeyEnd,
+	"[1~": KeyHome, "[4~": KeyEnd, "[7~": KeyHome, "[8~": KeyEnd,
+}
+
+// ParseKeys decodes raw terminal input into key presses
+// Unknown escape sequences are dropped; a lone ESC is reported as KeyEsc
+func ParseKeys(input []byte) []Key {
+	var keys []Key
+	for len(input) > 0 {
+		b := input[0]
+		switch {
+		case b == 0x1b:
+			key, size := parseEscape(input)
+			if key != nil {
+				keys = append(keys, *key)
+			}
+			input = input[size:]
+			continue
+		case b == '\r' || b == '\n':
+			keys = append(keys, Key{Type: KeyEnter})
+		case b == 0x7f || b == 0x08:
+			keys = append(keys, Key{Type: KeyBackspace})
+		case b == 0x03:
+			keys = append(keys, Key{Type: KeyCtrlC})
+		case b < 0x20:
+			// Other control characters are ignored
+		default:
+			r, size := utf8.DecodeRune(input)
+			if r != utf8.RuneError {
+				keys = append(keys, Key{Type: KeyRune, Rune: r})
+			}
+			input = input[size:]
+			continue
+		}
+		input = input[1:]
+	}
+	return keys
+}
+
+// parseEscape decodes an escape sequence at the start of input
+// Returns the key (nil if unknown) and the number of bytes consumed
+func parseEscape(input []byte) (*Key, int) {
+	if len(input) == 1 || (input[1] != '[' && input[1] != 'O') {
+		return &Key{Type: KeyEsc}, 1
+	}
+
+	// CSI/SS3 sequences end with a byte in the range @..~
+	for i := 2; i < len(input); i++ {
+		if input[i] >= '@' && input[i] <= '~' {
+			if keyType, ok := escapeSequences[string(input[1:i+1])]; ok {
+				return &Key{Type: keyType}, i + 1
+			}
+			return nil, i + 1
+		}
+	}
+	return nil, len(input)
+}
@@ -0,0 +1,30 @@
+package browse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Key
+	}{
+		{"arrows", "\x1b[A\x1b[B\x1bOC\x1b[D", []Key{{Type: KeyUp}, {Type: KeyDown}, {Type: KeyRight}, {Type: KeyLeft}}},
+		{"paging", "\x1b[5~\x1b[6~\x1b[H\x1b[4~", []Key{{Type: KeyPgUp}, {Type: KeyPgDn}, {Type: KeyHome}, {Type: KeyEnd}}},
+		{"lone escape", "\x1b", []Key{{Type: KeyEsc}}},
+		{"escape then rune", "\x1bq", []Key{{Type: KeyEsc}, {Type: KeyRune, Rune: 'q'}}},
+		{"controls", "\r\x7f\x03", []Key{{Type: KeyEnter}, {Type: KeyBackspace}, {Type: KeyCtrlC}}},
+		{"unicode runes", "/é", []Key{{Type: KeyRune, Rune: '/'}, {Type: KeyRune, Rune: 'é'}}},
+		{"unknown sequence dropped", "\x1b[15~j", []Key{{Type: KeyRune, Rune: 'j'}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseKeys([]byte(tt.input)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseKeys(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
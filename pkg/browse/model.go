@@ -0,0 +1,409 @@
+package browse
+
+import (
+	"sort"
+	"strings"
+)
+
+// Source loads journal data for the browser
+type Source struct {
+	Dates func() (map[string][]string, error)           // Month (YYYY-MM) to dates, as returned by planfile.DiscoverDates
+	Month func(month string) (map[string]string, error) // Content of each date section in a month, including its header
+}
+
+// Action tells the caller what to do after a key press
+type Action int
+
+const (
+	ActionNone Action = iota
+	ActionQuit
+	ActionOpen // Open the selected date in the editor
+)
+
+// row is one visible line of the month/day tree
+type row struct {
+	month string
+	date  string // Empty for month rows
+}
+
+// Model is the browser state: the month/day tree, selection, and search
+type Model struct {
+	source Source
+	today  string
+
+	months   []string            // Newest first
+	dates    map[string][]string // Month to dates, oldest first
+	expanded map[string]bool
+	rows     []row
+
+	cursor int // Selected row
+	offset int // First visible tree row
+	scroll int // First visible content line
+
+	searching bool
+	query     string
+	matches   map[string]bool // Dates matching the query (nil without a query)
+	cache     map[string]string
+
+	Status string // One-off message shown in the footer
+}
+
+// New loads the journal and selects today (or the latest date before it)
+func New(source Source, today string) (*Model, error) {
+	m := &Model{source: source, today: today, expanded: make(map[string]bool)}
+	if err := m.Reload(); err != nil {
+		return nil, err
+	}
+	m.JumpToToday()
+	return m, nil
+}
+
+// Reload re-reads the journal, keeping the selection when it still exists
+func (m *Model) Reload() error {
+	dates, err := m.source.Dates()
+	if err != nil {
+		return err
+	}
+
+	selected := m.selectedRow()
+	m.dates = dates
+	m.cache = make(map[string]string)
+	m.months = m.months[:0]
+	for month := range dates {
+		m.months = append(m.months, month)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(m.months)))
+
+	if m.query != "" {
+		m.search()
+	}
+	m.rebuild()
+	m.selectRow(selected)
+	return nil
+}
+
+// Selected returns the selected date, or empty string when a month is selected
+func (m *Model) Selected() string {
+	return m.selectedRow().date
+}
+
+// HandleKey updates the model for a key press
+func (m *Model) HandleKey(key Key) Action {
+	m.Status = ""
+	if m.searching {
+		m.handleSearchKey(key)
+		return ActionNone
+	}
+
+	switch key.Type {
+	case KeyCtrlC:
+		return ActionQuit
+	case KeyUp:
+		m.move(-1)
+	case KeyDown:
+		m.move(1)
+	case KeyPgUp:
+		m.move(-10)
+	case KeyPgDn:
+		m.move(10)
+	case KeyHome:
+		m.move(-len(m.rows))
+	case KeyEnd:
+		m.move(len(m.rows))
+	case KeyLeft:
+		m.collapse()
+	case KeyRight:
+		m.expand()
+	case KeyEnter:
+		if m.Selected() != "" {
+			return ActionOpen
+		}
+		m.toggle()
+	case KeyEsc:
+		m.setQuery("")
+	case KeyRune:
+		return m.handleRune(key.Rune)
+	}
+	return ActionNone
+}
+
+// handleRune handles single-letter commands
+func (m *Model) handleRune(r rune) Action {
+	switch r {
+	case 'q':
+		return ActionQuit
+	case 'k':
+		m.move(-1)
+	case 'j':
+		m.move(1)
+	case 'g':
+		m.move(-len(m.rows))
+	case 'G':
+		m.move(len(m.rows))
+	case 'h':
+		m.collapse()
+	case 'l':
+		m.expand()
+	case 'K':
+		m.scroll = max(0, m.scroll-1)
+	case 'J':
+		m.scroll++
+	case 't':
+		m.JumpToToday()
+	case 'e', 'o':
+		if m.Selected() != "" {
+			return ActionOpen
+		}
+		m.Status = "Select a day to edit"
+	case '/':
+		m.searching = true
+	}
+	return ActionNone
+}
+
+// handleSearchKey edits the query; the tree is filtered as you type
+func (m *Model) handleSearchKey(key Key) {
+	switch key.Type {
+	case KeyEnter:
+		m.searching = false
+	case KeyEsc, KeyCtrlC:
+		m.searching = false
+		m.setQuery("")
+	case KeyBackspace:
+		if query := []rune(m.query); len(query) > 0 {
+			m.setQuery(string(query[:len(query)-1]))
+		}
+	case KeyUp:
+		m.move(-1)
+	case KeyDown:
+		m.move(1)
+	case KeyRune:
+		m.setQuery(m.query + string(key.Rune))
+	}
+}
+
+// JumpToToday selects today, or the latest date before it when today has no entry
+func (m *Model) JumpToToday() {
+	target := ""
+	for _, month := range m.months {
+		for _, date := range m.dates[month] {
+			if date <= m.today && date > target && m.visibleDate(date) {
+				target = date
+			}
+		}
+	}
+	if target == "" {
+		m.Status = "No entries up to today"
+		return
+	}
+	if target != m.today {
+		m.Status = "No entry for today, showing " + target
+	}
+
+	m.expanded[target[:7]] = true
+	m.rebuild()
+	m.selectRow(row{month: target[:7], date: target})
+}
+
+// Content returns the lines shown for the selection: a day's section or a month overview
+func (m *Model) Content() []string {
+	selected := m.selectedRow()
+	if selected.month == "" {
+		return nil
+	}
+	if selected.date != "" {
+		content, err := m.dayContent(selected.date)
+		if err != nil {
+			return []string{"Error: " + err.Error()}
+		}
+		return strings.Split(strings.TrimRight(content, "\n"), "\n")
+	}
+
+	lines := []string{"# " + selected.month, ""}
+	for _, date := range m.dates[selected.month] {
+		if m.visibleDate(date) {
+			header, _, _ := strings.Cut(m.cachedOrEmpty(date), "\n")
+			lines = append(lines, strings.TrimSpace(strings.TrimPrefix(header, "##")))
+		}
+	}
+	return lines
+}
+
+// Query returns the search query and whether it's being typed
+func (m *Model) Query() (string, bool) {
+	return m.query, m.searching
+}
+
+// setQuery filters the tree to dates whose content contains the query
+func (m *Model) setQuery(query string) {
+	selected := m.selectedRow()
+	m.query = query
+	m.search()
+	m.rebuild()
+	m.selectRow(selected)
+	if !m.visibleDate(m.Selected()) || m.selectedRow().month == "" {
+		m.cursor = m.firstDateRow()
+	}
+}
+
+// search recomputes the matching dates for the current query
+func (m *Model) search() {
+	if m.query == "" {
+		m.matches = nil
+		return
+	}
+
+	needle := strings.ToLower(m.query)
+	m.matches = make(map[string]bool)
+	for _, dates := range m.dates {
+		for _, date := range dates {
+			content, err := m.dayContent(date)
+			if err == nil && strings.Contains(strings.ToLower(content), needle) {
+				m.matches[date] = true
+			}
+		}
+	}
+	if len(m.matches) == 0 {
+		m.Status = "No matches"
+	}
+}
+
+// MatchCount returns how many dates match the query
+func (m *Model) MatchCount() int {
+	return len(m.matches)
+}
+
+// visibleDate reports whether a date passes the search filter
+func (m *Model) visibleDate(date string) bool {
+	return m.matches == nil || m.matches[date]
+}
+
+// rebuild recomputes the visible rows; months are expanded while searching
+func (m *Model) rebuild() {
+	m.rows = m.rows[:0]
+	for _, month := range m.months {
+		var dates []string
+		for _, date := range m.dates[month] {
+			if m.visibleDate(date) {
+				dates = append(dates, date)
+			}
+		}
+		if len(dates) == 0 && m.matches != nil {
+			continue
+		}
+
+		m.rows = append(m.rows, row{month: month})
+		if m.expanded[month] || m.matches != nil {
+			for _, date := range dates {
+				m.rows = append(m.rows, row{month: month, date: date})
+			}
+		}
+	}
+	m.cursor = max(0, min(m.cursor, len(m.rows)-1))
+}
+
+// move moves the selection by delta rows
+func (m *Model) move(delta int) {
+	if len(m.rows) == 0 {
+		return
+	}
+	cursor := max(0, min(len(m.rows)-1, m.cursor+delta))
+	if cursor != m.cursor {
+		m.cursor = cursor
+		m.scroll = 0
+	}
+}
+
+// expand opens the selected month, or moves into it
+func (m *Model) expand() {
+	selected := m.selectedRow()
+	if selected.date != "" || selected.month == "" {
+		return
+	}
+	if !m.expanded[selected.month] {
+		m.expanded[selected.month] = true
+		m.rebuild()
+		return
+	}
+	m.move(1)
+}
+
+// collapse closes the selected month, or moves from a day to its month
+func (m *Model) collapse() {
+	selected := m.selectedRow()
+	if selected.month == "" {
+		return
+	}
+	if selected.date != "" {
+		m.selectRow(row{month: selected.month})
+		return
+	}
+	if m.expanded[selected.month] {
+		m.expanded[selected.month] = false
+		m.rebuild()
+	}
+}
+
+// toggle expands or collapses the selected month
+func (m *Model) toggle() {
+	selected := m.selectedRow()
+	if m.expanded[selected.month] {
+		m.collapse()
+	} else {
+		m.expand()
+	}
+}
+
+// selectedRow returns the row under the cursor (zero row if the tree is empty)
+func (m *Model) selectedRow() row {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return row{}
+	}
+	return m.rows[m.cursor]
+}
+
+// selectRow moves the cursor to a row if it's visible
+func (m *Model) selectRow(target row) {
+	for i, r := range m.rows {
+		if r == target {
+			if i != m.cursor {
+				m.scroll = 0
+			}
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// firstDateRow returns the index of the first day row, or 0
+func (m *Model) firstDateRow() int {
+	for i, r := range m.rows {
+		if r.date != "" {
+			return i
+		}
+	}
+	return 0
+}
+
+// dayContent returns a date section, loading its month once
+func (m *Model) dayContent(date string) (string, error) {
+	if content, ok := m.cache[date]; ok {
+		return content, nil
+	}
+	days, err := m.source.Month(date[:7])
+	if err != nil {
+		return "", err
+	}
+	// Every date of the month is cached, so days missing from the file aren't asked for again
+	for _, d := range m.dates[date[:7]] {
+		m.cache[d] = days[d]
+	}
+	m.cache[date] = days[date]
+	return days[date], nil
+}
+
+// cachedOrEmpty returns a date section, or empty string if it can't be read
+func (m *Model) cachedOrEmpty(date string) string {
+	content, _ := m.dayContent(date)
+	return content
+}
@@ -0,0 +1,188 @@
+package browse
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/abyss/plan-journal-cli/pkg/output"
+)
+
+// testSource is an in-memory journal
+func testSource() Source {
+	source, _ := countingSource()
+	return source
+}
+
+// countingSource is testSource that counts the months loaded
+func countingSource() (Source, *int) {
+	loads := 0
+	days := map[string]string{
+		"2026-01-05": "## 2026-01-05\n* Planned the quarter",
+		"2026-02-12": "## 2026-02-12 - Offsite\n* Met the team\n* Dinner",
+		"2026-02-13": "## 2026-02-13\n* Wrote the quarterly report",
+	}
+	return Source{
+		Dates: func() (map[string][]string, error) {
+			return map[string][]string{
+				"2026-01": {"2026-01-05"},
+				"2026-02": {"2026-02-12", "2026-02-13"},
+			}, nil
+		},
+		Month: func(month string) (map[string]string, error) {
+			loads++
+			content := make(map[string]string)
+			for date, text := range days {
+				if strings.HasPrefix(date, month+"-") {
+					content[date] = text
+				}
+			}
+			return content, nil
+		},
+	}, &loads
+}
+
+func keys(m *Model, input string) Action {
+	action := ActionNone
+	for _, key := range ParseKeys([]byte(input)) {
+		action = m.HandleKey(key)
+	}
+	return action
+}
+
+func TestModelJumpsToToday(t *testing.T) {
+	m, err := New(testSource(), "2026-02-13")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if m.Selected() != "2026-02-13" || m.Status != "" {
+		t.Errorf("Selected() = %q (status %q), want today", m.Selected(), m.Status)
+	}
+
+	// Without an entry today, the latest earlier date is selected
+	m, _ = New(testSource(), "2026-02-20")
+	if m.Selected() != "2026-02-13" || m.Status == "" {
+		t.Errorf("Selected() = %q (status %q), want 2026-02-13 with a status", m.Selected(), m.Status)
+	}
+}
+
+func TestModelNavigation(t *testing.T) {
+	m, _ := New(testSource(), "2026-02-13")
+
+	// Rows: 2026-02, 2026-02-12, 2026-02-13, 2026-01
+	keys(m, "k")
+	if m.Selected() != "2026-02-12" {
+		t.Errorf("after up: Selected() = %q", m.Selected())
+	}
+
+	keys(m, "h") // From a day to its month
+	if m.Selected() != "" || m.Content()[0] != "# 2026-02" {
+		t.Errorf("after left: Selected() = %q, content %v", m.Selected(), m.Content())
+	}
+
+	keys(m, "h") // Collapse the month
+	keys(m, "j") // Next row is now January
+	keys(m, "l") // Expand January
+	keys(m, "l") // Move into it
+	if m.Selected() != "2026-01-05" {
+		t.Errorf("after expanding January: Selected() = %q", m.Selected())
+	}
+
+	if action := keys(m, "e"); action != ActionOpen {
+		t.Errorf("e on a day = %v, want ActionOpen", action)
+	}
+	if action := keys(m, "q"); action != ActionQuit {
+		t.Errorf("q = %v, want ActionQuit", action)
+	}
+
+	keys(m, "t")
+	if m.Selected() != "2026-02-13" {
+		t.Errorf("after t: Selected() = %q", m.Selected())
+	}
+}
+
+func TestModelSearch(t *testing.T) {
+	m, _ := New(testSource(), "2026-02-13")
+
+	keys(m, "/quart")
+	if query, searching := m.Query(); query != "quart" || !searching {
+		t.Errorf("Query() = %q, %v", query, searching)
+	}
+	if m.MatchCount() != 2 {
+		t.Errorf("MatchCount() = %d, want 2", m.MatchCount())
+	}
+
+	// The tree only holds matching days, with their months expanded
+	var visible []string
+	for _, r := range m.rows {
+		if r.date != "" {
+			visible = append(visible, r.date)
+		}
+	}
+	if strings.Join(visible, ",") != "2026-02-13,2026-01-05" {
+		t.Errorf("visible dates = %v", visible)
+	}
+
+	keys(m, "\x7f\x7f\x7f\x7f\x7fteam\r")
+	if m.MatchCount() != 1 || m.Selected() != "2026-02-12" {
+		t.Errorf("after refining: MatchCount() = %d, Selected() = %q", m.MatchCount(), m.Selected())
+	}
+
+	keys(m, "\x1b")
+	if query, _ := m.Query(); query != "" || m.MatchCount() != 0 {
+		t.Errorf("after Esc: query %q, %d matches", query, m.MatchCount())
+	}
+}
+
+func TestModelLoadsEachMonthOnce(t *testing.T) {
+	source, loads := countingSource()
+	m, _ := New(source, "2026-02-13")
+
+	// Searching reads every day, but each month is only loaded once
+	keys(m, "/report")
+	keys(m, "\x7f\x7f\x7f\x7f\x7f\x7fteam")
+	if *loads != 2 {
+		t.Errorf("months loaded %d times, want 2", *loads)
+	}
+
+	// Reloading forgets the cached days
+	keys(m, "\x1b")
+	if err := m.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	m.cachedOrEmpty("2026-02-12")
+	if *loads != 3 {
+		t.Errorf("months loaded %d times after Reload(), want 3", *loads)
+	}
+}
+
+func TestModelView(t *testing.T) {
+	output.SetColorsDisabled(true)
+	defer output.SetColorsDisabled(false)
+
+	m, _ := New(testSource(), "2026-02-13")
+	lines := m.View(60, 10)
+	if len(lines) != 10 {
+		t.Fatalf("View() returned %d lines, want 10", len(lines))
+	}
+	for i, line := range lines {
+		if n := utf8.RuneCountInString(line); n > 60 {
+			t.Errorf("line %d is %d characters wide: %q", i, n, line)
+		}
+	}
+
+	view := strings.Join(lines, "\n")
+	for _, want := range []string{">  2026-02-13 Fri", "* Wrote the quarterly report", "▸ 2026-01 (1)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	got := wrap("one two three four", 9)
+	want := []string{"one two", "three", "four"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("wrap() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,102 @@
+package browse
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// Escape sequences for the alternate screen and cursor visibility
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l"
+	leaveScreen = "\x1b[?25h\x1b[?1049l"
+	clearScreen = "\x1b[H\x1b[2J"
+)
+
+// pollInterval is how often the loop checks for resizes while waiting for input
+const pollInterval = 100 * time.Millisecond
+
+// ErrNotTerminal is returned when stdin or stdout isn't an interactive terminal
+var ErrNotTerminal = errors.New("plan browse needs an interactive terminal")
+
+// Run shows the browser until the user quits
+// open is called with the terminal restored to normal mode, so it can run an editor
+func Run(m *Model, open func(date string) error) error {
+	in, out := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	if !term.IsTerminal(in) || !term.IsTerminal(out) {
+		return ErrNotTerminal
+	}
+
+	state, err := term.MakeRaw(in)
+	if err != nil {
+		return fmt.Errorf("failed to set up terminal: %w", err)
+	}
+	fmt.Print(enterScreen)
+	defer func() {
+		fmt.Print(leaveScreen)
+		_ = term.Restore(in, state)
+	}()
+
+	resized, stopResize := notifyResize()
+	defer stopResize()
+
+	buf := make([]byte, 256)
+	for {
+		draw(m, out)
+
+		// Wait for input, redrawing if the terminal is resized meanwhile
+		for !waitForInput(in, pollInterval) {
+			select {
+			case <-resized:
+				draw(m, out)
+			default:
+			}
+		}
+
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range ParseKeys(buf[:n]) {
+			switch m.HandleKey(key) {
+			case ActionQuit:
+				return nil
+			case ActionOpen:
+				date := m.Selected()
+
+				// Hand the terminal to the editor
+				fmt.Print(leaveScreen)
+				_ = term.Restore(in, state)
+				openErr := open(date)
+				if state, err = term.MakeRaw(in); err != nil {
+					return fmt.Errorf("failed to set up terminal: %w", err)
+				}
+				fmt.Print(enterScreen)
+
+				if err := m.Reload(); err != nil {
+					m.Status = "Failed to reload: " + err.Error()
+				} else if openErr != nil {
+					m.Status = "Editor failed: " + openErr.Error()
+				} else {
+					m.Status = "Edited " + date
+				}
+			}
+		}
+	}
+}
+
+// draw renders the model to the full terminal
+func draw(m *Model, out int) {
+	width, height, err := term.GetSize(out)
+	if err != nil {
+		width, height = 80, 24
+	}
+	lines := m.View(width, height)
+	// Raw mode doesn't translate \n, so each line returns to column 0 explicitly
+	fmt.Print(clearScreen + strings.Join(lines, "\r\n"))
+}
@@ -0,0 +1,27 @@
+//go:build !windows
+
+package browse
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// waitForInput reports whether fd has input ready within timeout
+func waitForInput(fd int, timeout time.Duration) bool {
+	fds := []unix.PollFd{{Fd: int32(fd), Events: unix.POLLIN}}
+	n, err := unix.Poll(fds, int(timeout.Milliseconds()))
+	// Treat errors (e.g. EINTR from SIGWINCH) as "no input yet"
+	return err == nil && n > 0
+}
+
+// notifyResize returns a channel that receives when the terminal is resized
+func notifyResize() (<-chan os.Signal, func()) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGWINCH)
+	return ch, func() { signal.Stop(ch) }
+}
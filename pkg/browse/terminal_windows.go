@@ -0,0 +1,18 @@
+//go:build windows
+
+package browse
+
+import (
+	"os"
+	"time"
+)
+
+// waitForInput always reports input as ready; reads block until a key is pressed
+func waitForInput(fd int, timeout time.Duration) bool {
+	return true
+}
+
+// notifyResize is a no-op on Windows; the view is resized on the next key press
+func notifyResize() (<-chan os.Signal, func()) {
+	return nil, func() {}
+}
@@ -0,0 +1,197 @@
+package browse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/output"
+)
+
+// treeWidth is the width of the month/day tree, including its border
+const treeWidth = 24
+
+// helpText lists the keys in the footer
+const helpText = "↑↓ move  ←→ fold  / search  t today  e edit  J/K scroll  q quit"
+
+// View renders the browser as exactly height lines of at most width visible characters
+func (m *Model) View(width, height int) []string {
+	if width < treeWidth+10 || height < 4 {
+		return []string{fit("Terminal too small", width)}
+	}
+
+	bodyHeight := height - 2
+	m.scrollTree(bodyHeight)
+
+	lines := []string{output.Header(fit(" plan browse", width))}
+
+	content := m.contentLines(width-treeWidth-1, bodyHeight)
+	for i := 0; i < bodyHeight; i++ {
+		left := strings.Repeat(" ", treeWidth-1)
+		if index := m.offset + i; index < len(m.rows) {
+			left = m.treeLine(index)
+		}
+		right := ""
+		if i < len(content) {
+			right = content[i]
+		}
+		lines = append(lines, left+output.Info("│")+right)
+	}
+
+	return append(lines, m.footer(width))
+}
+
+// scrollTree keeps the cursor inside the visible part of the tree
+func (m *Model) scrollTree(visible int) {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+visible {
+		m.offset = m.cursor - visible + 1
+	}
+	m.offset = max(0, min(m.offset, len(m.rows)-visible))
+}
+
+// treeLine renders one row of the tree, treeWidth-1 characters wide
+func (m *Model) treeLine(index int) string {
+	r := m.rows[index]
+
+	var text string
+	if r.date == "" {
+		marker := "▸"
+		if m.expanded[r.month] || m.matches != nil {
+			marker = "▾"
+		}
+		text = fmt.Sprintf("%s %s (%d)", marker, r.month, m.visibleCount(r.month))
+	} else {
+		weekday := ""
+		if date, err := time.Parse("2006-01-02", r.date); err == nil {
+			weekday = date.Format("Mon")
+		}
+		text = fmt.Sprintf("  %s %s", r.date, weekday)
+	}
+
+	if index == m.cursor {
+		if output.ColorsDisabled() {
+			return fit(">"+text, treeWidth-1)
+		}
+		return output.Selected(fit(" "+text, treeWidth-1))
+	}
+
+	line := fit(" "+text, treeWidth-1)
+	switch {
+	case r.date == m.today:
+		return output.DateGreen(line)
+	case r.date == "":
+		return output.Bold(line)
+	}
+	return line
+}
+
+// visibleCount returns how many dates of a month pass the search filter
+func (m *Model) visibleCount(month string) int {
+	count := 0
+	for _, date := range m.dates[month] {
+		if m.visibleDate(date) {
+			count++
+		}
+	}
+	return count
+}
+
+// contentLines wraps the selection's content to width and returns the visible part
+func (m *Model) contentLines(width, height int) []string {
+	var wrapped []string
+	for _, line := range m.Content() {
+		wrapped = append(wrapped, wrap(line, width-1)...)
+	}
+
+	m.scroll = max(0, min(m.scroll, len(wrapped)-height))
+	end := min(len(wrapped), m.scroll+height)
+
+	lines := make([]string, 0, end-m.scroll)
+	for _, line := range wrapped[m.scroll:end] {
+		lines = append(lines, " "+m.styleContent(line))
+	}
+	return lines
+}
+
+// styleContent colors headers and highlights search matches in one content line
+func (m *Model) styleContent(line string) string {
+	if strings.HasPrefix(line, "#") {
+		return output.Bold(line)
+	}
+	if m.query == "" {
+		return line
+	}
+
+	// Highlight every case-insensitive occurrence of the query
+	lower := strings.ToLower(line)
+	needle := strings.ToLower(m.query)
+	if len(lower) != len(line) {
+		return line // Case mapping changed byte offsets, skip highlighting
+	}
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, needle)
+		if i < 0 {
+			b.WriteString(line)
+			return b.String()
+		}
+		b.WriteString(line[:i])
+		b.WriteString(output.Highlight(line[i : i+len(needle)]))
+		line, lower = line[i+len(needle):], lower[i+len(needle):]
+	}
+}
+
+// footer shows the search prompt, a status message, or the key help
+func (m *Model) footer(width int) string {
+	query, searching := m.Query()
+	switch {
+	case searching:
+		return fit(fmt.Sprintf("/%s_  (%d matches, Enter to keep, Esc to clear)", query, m.MatchCount()), width)
+	case m.Status != "":
+		return output.Warning(fit(m.Status, width))
+	case query != "":
+		return output.Info(fit(fmt.Sprintf("Filter: %q (%d matches, Esc to clear)  %s", query, m.MatchCount(), helpText), width))
+	}
+	return output.Info(fit(helpText, width))
+}
+
+// fit truncates or pads s to exactly width characters
+func fit(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) > width {
+		if width <= 1 {
+			return string(runes[:width])
+		}
+		return string(runes[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-len(runes))
+}
+
+// wrap splits a line into pieces of at most width characters, breaking at spaces when possible
+func wrap(line string, width int) []string {
+	line = strings.ReplaceAll(line, "\t", "    ")
+	runes := []rune(line)
+	if width <= 0 || len(runes) <= width {
+		return []string{line}
+	}
+
+	var lines []string
+	for len(runes) > width {
+		cut := width
+		for i := width; i > width/2; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		lines = append(lines, string(runes[:cut]))
+		runes = runes[cut:]
+		if len(runes) > 0 && runes[0] == ' ' {
+			runes = runes[1:]
+		}
+	}
+	return append(lines, string(runes))
+}
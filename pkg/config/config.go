@@ -5,7 +5,13 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+	"github.com/abyss/plan-journal-cli/pkg/output"
 )
 
 // DefaultPreamble is the default preamble text for plan files (empty by default)
@@ -13,19 +19,49 @@ const DefaultPreamble = ""
 
 // Config holds configuration loaded from file
 type Config struct {
-	Preamble   string
-	Editor     string
-	EditorType string
-	Location   string
-	NoColor    string
+	Preamble       string
+	Editor         string
+	EditorType     string
+	EditorFallback string
+	Location       string
+	NoColor        string
+	DropEmptyDay   string
+	GitAutoCommit  string
+	GitRemote      string
+	GitBranch      string
+	HooksDir       string
+	HookTimeout    string
+	NoHooks        string
+	Cursor         string
+	Seed           string
+	WeekStart      string
+	Pager          string
+	Theme          string
+	PassphraseCmd  string
+	ServeAddr      string
+	ServeToken     string
+	ServeReadOnly  string
+	ServeHosts     string
+
+	CustomEditors     map[string]EditorTemplate // PLAN_CUSTOM_EDITOR_<NAME>=<template>
+	CustomEditorTypes map[string]string         // PLAN_CUSTOM_EDITOR_<NAME>_TYPE=terminal|gui
+	HookCommands      map[string]string         // PLAN_HOOK_<EVENT>=<command>, keyed by event name (e.g. pre-edit)
+	ColorOverrides    map[string]string         // PLAN_COLOR_<ROLE>=<style>, keyed by role name (e.g. task-open)
+	TrustedProjects   []string                  // PLAN_TRUSTED_PROJECT=<dir>, one line per project (see plan trust)
+	Ignored           []string                  // Command keys skipped because the project isn't trusted
 }
 
-var loadedConfig *Config
-var cachedConfigPath string
-var cachedConfigFlag string
+// hookPrefix is the config key prefix for lifecycle hook commands
+const hookPrefix = "PLAN_HOOK_"
+
+// colorPrefix is the config key prefix for theme role overrides
+const colorPrefix = "PLAN_COLOR_"
+
+// customEditorPrefix is the config key prefix for user-registered editors
+const customEditorPrefix = "PLAN_CUSTOM_EDITOR_"
 
 // GetConfigPath returns the config file path
-// Priority: configFlag > PLAN_CONFIG env var > ~/plans/.config
+// Priority: configFlag > PLAN_CONFIG env var > project-local > ~/plans/.config
 func GetConfigPath(configFlag string) string {
 	// Priority 1: Command-line flag
 	if configFlag != "" {
@@ -37,7 +73,19 @@ func GetConfigPath(configFlag string) string {
 		return expandPath(envConfigPath)
 	}
 
-	// Priority 3: Default to ~/plans/.config
+	// Priority 3: Project-local .plan.config or .plans/.config
+	if project := DiscoverProject(); project != nil {
+		if projectConfig := project.ProjectConfigFile(); projectConfig != "" {
+			return projectConfig
+		}
+	}
+
+	// Priority 4: Default to ~/plans/.config
+	return defaultConfigPath()
+}
+
+// defaultConfigPath returns ~/plans/.config
+func defaultConfigPath() string {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		log.Fatalf("cannot determine home directory: %v", err)
@@ -45,25 +93,59 @@ func GetConfigPath(configFlag string) string {
 	return filepath.Join(homeDir, "plans", ".config")
 }
 
+// UserConfigPath returns the user's own config file: the one chosen with --config or
+// PLAN_CONFIG, or ~/plans/.config. Unlike GetConfigPath it never returns a project's config
+func UserConfigPath(configFlag string) string {
+	if configFlag != "" {
+		return expandPath(configFlag)
+	}
+	if envConfigPath := os.Getenv("PLAN_CONFIG"); envConfigPath != "" {
+		return expandPath(envConfigPath)
+	}
+	return defaultConfigPath()
+}
+
 // loadConfig loads configuration from config file
-// Priority: configFlag > PLAN_CONFIG env var > ~/plans/.config
+// Priority: configFlag > PLAN_CONFIG env var > project-local > ~/plans/.config
+// The file is read on each call, so a change to the flag, environment or working directory is always seen
 func loadConfig(configFlag string) *Config {
 	// Get config path
 	configPath := GetConfigPath(configFlag)
 
-	// Return cached config if path and flag haven't changed
-	if loadedConfig != nil && cachedConfigPath == configPath && cachedConfigFlag == configFlag {
-		return loadedConfig
+	// A project's config comes with the repository, so it can't run commands until trusted
+	untrusted := false
+	if !explicitConfig(configFlag) {
+		if project := DiscoverProject(); project != nil && project.ProjectConfigFile() == configPath {
+			untrusted = !project.Trusted(configFlag)
+		}
+	}
+	return readConfigFile(configPath, untrusted)
+}
+
+// commandKeys are the config keys that run commands; an untrusted project config can't set them
+var commandKeys = []string{"PLAN_EDITOR", "PLAN_EDITOR_FALLBACK", "PLAN_HOOKS_DIR", "PLAN_PAGER", "PLAN_PASSPHRASE_COMMAND"}
+
+// isCommandKey reports whether a config key runs a command, including custom editors and hooks
+func isCommandKey(key string) bool {
+	if slices.Contains(commandKeys, key) || strings.HasPrefix(key, customEditorPrefix) {
+		return true
 	}
+	return strings.HasPrefix(key, hookPrefix) && key != "PLAN_HOOK_TIMEOUT"
+}
 
-	// Update cache
-	cachedConfigPath = configPath
-	cachedConfigFlag = configFlag
-	loadedConfig = &Config{}
+// readConfigFile parses a config file; a missing file gives an empty config
+// With untrusted set, command keys are skipped and listed in Ignored
+func readConfigFile(configPath string, untrusted bool) *Config {
+	cfg := &Config{
+		CustomEditors:     make(map[string]EditorTemplate),
+		CustomEditorTypes: make(map[string]string),
+		HookCommands:      make(map[string]string),
+		ColorOverrides:    make(map[string]string),
+	}
 
 	file, err := os.Open(configPath)
 	if err != nil {
-		return loadedConfig
+		return cfg
 	}
 	defer file.Close()
 
@@ -85,25 +167,94 @@ func loadConfig(configFlag string) *Config {
 		key := strings.TrimSpace(parts[0])
 		value := strings.TrimSpace(parts[1])
 
+		if untrusted && isCommandKey(key) {
+			cfg.Ignored = append(cfg.Ignored, key)
+			continue
+		}
+
 		switch key {
 		case "PLAN_PREAMBLE":
-			loadedConfig.Preamble = value
+			cfg.Preamble = value
 		case "PLAN_EDITOR":
-			loadedConfig.Editor = value
+			cfg.Editor = value
 		case "PLAN_EDITOR_TYPE":
-			loadedConfig.EditorType = value
+			cfg.EditorType = value
+		case "PLAN_EDITOR_FALLBACK":
+			cfg.EditorFallback = value
 		case "PLAN_LOCATION":
-			loadedConfig.Location = value
+			cfg.Location = value
 		case "PLAN_NO_COLOR":
-			loadedConfig.NoColor = value
+			cfg.NoColor = value
+		case "PLAN_DROP_EMPTY_DAY":
+			cfg.DropEmptyDay = value
+		case "PLAN_GIT_AUTOCOMMIT":
+			cfg.GitAutoCommit = value
+		case "PLAN_GIT_REMOTE":
+			cfg.GitRemote = value
+		case "PLAN_GIT_BRANCH":
+			cfg.GitBranch = value
+		case "PLAN_HOOKS_DIR":
+			cfg.HooksDir = value
+		case "PLAN_HOOK_TIMEOUT":
+			cfg.HookTimeout = value
+		case "PLAN_NO_HOOKS":
+			cfg.NoHooks = value
+		case "PLAN_CURSOR":
+			cfg.Cursor = value
+		case "PLAN_WEEK_START":
+			cfg.WeekStart = value
+		case "PLAN_SEED":
+			cfg.Seed = value
+		case "PLAN_PAGER":
+			cfg.Pager = value
+		case "PLAN_THEME":
+			cfg.Theme = value
+		case "PLAN_PASSPHRASE_COMMAND":
+			cfg.PassphraseCmd = value
+		case "PLAN_SERVE_ADDR":
+			cfg.ServeAddr = value
+		case "PLAN_SERVE_TOKEN":
+			cfg.ServeToken = value
+		case "PLAN_SERVE_READ_ONLY":
+			cfg.ServeReadOnly = value
+		case "PLAN_SERVE_HOSTS":
+			cfg.ServeHosts = value
+		case "PLAN_TRUSTED_PROJECT":
+			cfg.TrustedProjects = append(cfg.TrustedProjects, value)
+		default:
+			if name, found := strings.CutPrefix(key, customEditorPrefix); found && name != "" {
+				parseCustomEditor(cfg, name, value)
+			} else if event, found := strings.CutPrefix(key, hookPrefix); found && event != "" {
+				// PLAN_HOOK_PRE_EDIT -> pre-edit
+				cfg.HookCommands[strings.ReplaceAll(strings.ToLower(event), "_", "-")] = value
+			} else if role, found := strings.CutPrefix(key, colorPrefix); found && role != "" {
+				// PLAN_COLOR_TASK_OPEN -> task-open
+				cfg.ColorOverrides[strings.ReplaceAll(strings.ToLower(role), "_", "-")] = value
+			}
 		}
 	}
 
-	return loadedConfig
+	return cfg
+}
+
+// parseCustomEditor records a user-registered editor definition
+// Names are case-insensitive; a _TYPE suffix sets the editor type
+func parseCustomEditor(cfg *Config, name, value string) {
+	if base, found := strings.CutSuffix(name, "_TYPE"); found && base != "" {
+		cfg.CustomEditorTypes[strings.ToLower(base)] = normalizeEditorType(value)
+		return
+	}
+
+	name = strings.ToLower(name)
+	cfg.CustomEditors[name] = EditorTemplate{
+		Name:    name,
+		Command: value,
+		Custom:  true,
+	}
 }
 
 // GetPlansDirectory resolves the plans directory location
-// Priority: flag > env > config file > default (~/plans/)
+// Priority: flag > env > explicit config file > project-local > config file > default (~/plans/)
 func GetPlansDirectory(configFlag, locationFlag string) string {
 	// Priority 1: Command-line flag
 	if locationFlag != "" {
@@ -115,13 +266,23 @@ func GetPlansDirectory(configFlag, locationFlag string) string {
 		return expandPath(envPath)
 	}
 
-	// Priority 3: Config file
+	// Priority 3: A config file chosen with --config or PLAN_CONFIG
 	cfg := loadConfig(configFlag)
+	if explicitConfig(configFlag) && cfg.Location != "" {
+		return expandPath(cfg.Location)
+	}
+
+	// Priority 4: Project-local journal
+	if projectDir := projectPlansDirectory(configFlag); projectDir != "" {
+		return projectDir
+	}
+
+	// Priority 5: Config file
 	if cfg.Location != "" {
 		return expandPath(cfg.Location)
 	}
 
-	// Priority 4: Default
+	// Priority 6: Default
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		log.Fatalf("cannot determine home directory: %v", err)
@@ -129,12 +290,44 @@ func GetPlansDirectory(configFlag, locationFlag string) string {
 	return filepath.Join(homeDir, "plans")
 }
 
+// explicitConfig reports whether the config file was chosen with --config or PLAN_CONFIG
+func explicitConfig(configFlag string) bool {
+	return configFlag != "" || os.Getenv("PLAN_CONFIG") != ""
+}
+
+// projectPlansDirectory returns the plans directory of a discovered project
+// A PLAN_LOCATION in the project's own config file (relative to the project root)
+// wins over a .plans/ directory. Returns empty string if no project applies.
+func projectPlansDirectory(configFlag string) string {
+	project := DiscoverProject()
+	if project == nil {
+		return ""
+	}
+
+	// Only honour PLAN_LOCATION when the project's config file is the one in use
+	if projectConfig := project.ProjectConfigFile(); projectConfig != "" && GetConfigPath(configFlag) == projectConfig {
+		cfg := loadConfig(configFlag)
+		if cfg.Location != "" {
+			location := expandPath(cfg.Location)
+			if !filepath.IsAbs(location) {
+				location = filepath.Join(project.Root, location)
+			}
+			return location
+		}
+	}
+
+	return project.PlansDir
+}
+
+// DefaultEditorFallbacks are tried in order when the selected editor is not installed
+var DefaultEditorFallbacks = []string{"vim", "vi", "nano"}
+
 // GetEditorCommand resolves the editor command template
-// Priority: flag > env > config file > default (vim)
+// Priority: flag > env > config file > VISUAL > EDITOR > default (vim)
 func GetEditorCommand(configFlag, editorFlag string) (string, error) {
 	// Priority 1: Command-line flag
 	if editorFlag != "" {
-		return resolveEditorCommand(editorFlag)
+		return resolveEditorCommand(configFlag, editorFlag)
 	}
 
 	// Priority 2: Environment variable
@@ -146,13 +339,71 @@ func GetEditorCommand(configFlag, editorFlag string) (string, error) {
 	// Priority 3: Config file
 	cfg := loadConfig(configFlag)
 	if cfg.Editor != "" {
-		return resolveEditorCommand(cfg.Editor)
+		return resolveEditorCommand(configFlag, cfg.Editor)
+	}
+
+	// Priority 4: Standard VISUAL and EDITOR environment variables
+	for _, key := range []string{"VISUAL", "EDITOR"} {
+		if envEditor := strings.TrimSpace(os.Getenv(key)); envEditor != "" {
+			return templateFromCommand(envEditor), nil
+		}
 	}
 
-	// Priority 4: Default to vim
+	// Priority 5: Default to vim
 	return BuiltInEditors["vim"].Command, nil
 }
 
+// templateFromCommand turns a plain editor command (as found in VISUAL or EDITOR)
+// into a template. A bare binary of a known editor gets that editor's template with
+// line support; any other command without placeholders gets the file appended.
+func templateFromCommand(command string) string {
+	if strings.Contains(command, "%file%") {
+		return command
+	}
+
+	fields := strings.Fields(command)
+	if len(fields) == 1 {
+		if tmpl, ok := LookupEditorByBinary(fields[0]); ok {
+			// Keep the user's binary (it may be a full path) with the known arguments
+			_, args, _ := strings.Cut(tmpl.Command, " ")
+			return fields[0] + " " + args
+		}
+	}
+
+	return command + " %file%"
+}
+
+// GetEditorFallbacks resolves the editor templates to try when the selected editor is not installed
+// Priority: env (PLAN_EDITOR_FALLBACK) > config file > default (vim, vi, nano)
+// Values are comma-separated editor names or templates
+func GetEditorFallbacks(configFlag string) []string {
+	value := os.Getenv("PLAN_EDITOR_FALLBACK")
+	if value == "" {
+		value = loadConfig(configFlag).EditorFallback
+	}
+
+	names := DefaultEditorFallbacks
+	if value != "" {
+		names = nil
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+
+	editors := GetEditors(configFlag)
+	fallbacks := make([]string, 0, len(names))
+	for _, name := range names {
+		if tmpl, ok := editors[name]; ok {
+			fallbacks = append(fallbacks, tmpl.Command)
+		} else {
+			fallbacks = append(fallbacks, templateFromCommand(name))
+		}
+	}
+	return fallbacks
+}
+
 // GetEditorType resolves the editor type (terminal, gui, or auto)
 // Priority: flag > env > config file > default (auto)
 func GetEditorType(configFlag, editorTypeFlag string) string {
@@ -212,11 +463,11 @@ func GetPreamble(configFlag, preambleFlag string) string {
 }
 
 // resolveEditorCommand resolves an editor specification to a command template
-// If it's a built-in editor name, return its template
+// If it's a built-in or user-registered editor name, return its template
 // Otherwise, treat it as a custom template
-func resolveEditorCommand(editor string) (string, error) {
-	// Check if it's a built-in editor name
-	if template, ok := BuiltInEditors[editor]; ok {
+func resolveEditorCommand(configFlag, editor string) (string, error) {
+	// Check if it's a known editor name
+	if template, ok := GetEditors(configFlag)[editor]; ok {
 		return template.Command, nil
 	}
 
@@ -252,6 +503,367 @@ func GetNoColor(configFlag, noColorFlag string) bool {
 	return false
 }
 
+// GetCursorMode resolves where the editor opens in a date section (end, new, or top)
+// Priority: PLAN_CURSOR env > config file > default (new)
+func GetCursorMode(configFlag string) string {
+	// Priority 1: Environment variable
+	if envCursor := os.Getenv("PLAN_CURSOR"); envCursor != "" {
+		return envCursor
+	}
+
+	// Priority 2: Config file
+	cfg := loadConfig(configFlag)
+	if cfg.Cursor != "" {
+		return cfg.Cursor
+	}
+
+	// Priority 3: Default (new line after the last entry)
+	return "new"
+}
+
+// GetSeed resolves the prefix inserted on the new line in "new" cursor mode
+// Priority: PLAN_SEED env > config file > default (empty)
+func GetSeed(configFlag string) string {
+	// Priority 1: Environment variable
+	if envSeed := os.Getenv("PLAN_SEED"); envSeed != "" {
+		return envSeed
+	}
+
+	// Priority 2: Config file
+	cfg := loadConfig(configFlag)
+	return cfg.Seed
+}
+
+// DefaultWeekStart is the first day of the week in calendar views
+const DefaultWeekStart = time.Monday
+
+// GetWeekStart resolves the first day of the week for calendar views
+// Priority: weekStartFlag > PLAN_WEEK_START env > config file > default (Monday)
+func GetWeekStart(configFlag, weekStartFlag string) (time.Weekday, error) {
+	// Priority 1: Command-line flag
+	if weekStartFlag != "" {
+		return dateutil.ParseWeekday(weekStartFlag)
+	}
+
+	// Priority 2: Environment variable
+	if envWeekStart := os.Getenv("PLAN_WEEK_START"); envWeekStart != "" {
+		return dateutil.ParseWeekday(envWeekStart)
+	}
+
+	// Priority 3: Config file
+	cfg := loadConfig(configFlag)
+	if cfg.WeekStart != "" {
+		return dateutil.ParseWeekday(cfg.WeekStart)
+	}
+
+	// Priority 4: Default
+	return DefaultWeekStart, nil
+}
+
+// GetDropEmptyDay resolves whether an untouched empty day header is removed after editing
+// Priority: PLAN_DROP_EMPTY_DAY env > config file > default (false)
+func GetDropEmptyDay(configFlag string) bool {
+	// Priority 1: Environment variable
+	if envDrop := os.Getenv("PLAN_DROP_EMPTY_DAY"); envDrop != "" {
+		return isTruthy(envDrop)
+	}
+
+	// Priority 2: Config file
+	cfg := loadConfig(configFlag)
+	if cfg.DropEmptyDay != "" {
+		return isTruthy(cfg.DropEmptyDay)
+	}
+
+	// Priority 3: Default (keep the header)
+	return false
+}
+
+// GetGitAutoCommit resolves whether plan file changes are committed automatically
+// Priority: PLAN_GIT_AUTOCOMMIT env > config file > default (false)
+func GetGitAutoCommit(configFlag string) bool {
+	// Priority 1: Environment variable
+	if envAutoCommit := os.Getenv("PLAN_GIT_AUTOCOMMIT"); envAutoCommit != "" {
+		return isTruthy(envAutoCommit)
+	}
+
+	// Priority 2: Config file
+	cfg := loadConfig(configFlag)
+	if cfg.GitAutoCommit != "" {
+		return isTruthy(cfg.GitAutoCommit)
+	}
+
+	// Priority 3: Default (disabled)
+	return false
+}
+
+// GetGitRemote resolves the git remote used by plan sync
+// Priority: PLAN_GIT_REMOTE env > config file > default (origin)
+func GetGitRemote(configFlag string) string {
+	// Priority 1: Environment variable
+	if envRemote := os.Getenv("PLAN_GIT_REMOTE"); envRemote != "" {
+		return envRemote
+	}
+
+	// Priority 2: Config file
+	cfg := loadConfig(configFlag)
+	if cfg.GitRemote != "" {
+		return cfg.GitRemote
+	}
+
+	// Priority 3: Default
+	return "origin"
+}
+
+// GetGitBranch resolves the remote branch used by plan sync
+// Priority: PLAN_GIT_BRANCH env > config file > default (empty, meaning the current branch)
+func GetGitBranch(configFlag string) string {
+	// Priority 1: Environment variable
+	if envBranch := os.Getenv("PLAN_GIT_BRANCH"); envBranch != "" {
+		return envBranch
+	}
+
+	// Priority 2: Config file
+	cfg := loadConfig(configFlag)
+	return cfg.GitBranch
+}
+
+// GetHooksDirectory resolves the directory containing hook executables, or "" for none
+// Priority: PLAN_HOOKS_DIR env > config file > default (<plans directory>/.hooks, except
+// in a project that isn't trusted)
+func GetHooksDirectory(configFlag, plansDir string) string {
+	// Priority 1: Environment variable
+	if envDir := os.Getenv("PLAN_HOOKS_DIR"); envDir != "" {
+		return expandPath(envDir)
+	}
+
+	// Priority 2: Config file
+	cfg := loadConfig(configFlag)
+	if cfg.HooksDir != "" {
+		return expandPath(cfg.HooksDir)
+	}
+
+	// Priority 3: Default, unless anyone who can change the project could have put hooks there
+	if project := DiscoverProject(); project != nil && !project.Trusted(configFlag) && project.Contains(plansDir) {
+		return ""
+	}
+	return filepath.Join(plansDir, ".hooks")
+}
+
+// GetHookCommands returns hook commands defined in the config file, keyed by event name
+func GetHookCommands(configFlag string) map[string]string {
+	return loadConfig(configFlag).HookCommands
+}
+
+// GetIgnoredProjectKeys returns the command keys skipped in an untrusted project's config file
+func GetIgnoredProjectKeys(configFlag string) []string {
+	return loadConfig(configFlag).Ignored
+}
+
+// DefaultHookTimeout is how long a hook may run before it is stopped
+const DefaultHookTimeout = 30 * time.Second
+
+// GetHookTimeout resolves the per-hook timeout
+// Priority: PLAN_HOOK_TIMEOUT env > config file > default (30s)
+// Accepts Go durations (e.g. "10s", "1m") or a plain number of seconds
+func GetHookTimeout(configFlag string) time.Duration {
+	value := os.Getenv("PLAN_HOOK_TIMEOUT")
+	if value == "" {
+		value = loadConfig(configFlag).HookTimeout
+	}
+	if value == "" {
+		return DefaultHookTimeout
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if duration, err := time.ParseDuration(value); err == nil && duration > 0 {
+		return duration
+	}
+	return DefaultHookTimeout
+}
+
+// GetNoHooks resolves whether lifecycle hooks are disabled
+// Priority: flag > PLAN_NO_HOOKS env > config file > default (false)
+func GetNoHooks(configFlag, noHooksFlag string) bool {
+	// Priority 1: Command-line flag
+	if noHooksFlag != "" {
+		return isTruthy(noHooksFlag)
+	}
+
+	// Priority 2: Environment variable
+	if envNoHooks := os.Getenv("PLAN_NO_HOOKS"); envNoHooks != "" {
+		return isTruthy(envNoHooks)
+	}
+
+	// Priority 3: Config file
+	cfg := loadConfig(configFlag)
+	if cfg.NoHooks != "" {
+		return isTruthy(cfg.NoHooks)
+	}
+
+	// Priority 4: Default (hooks enabled)
+	return false
+}
+
+// GetTheme resolves the color theme name
+// Priority: PLAN_THEME env > config file > default (minimal)
+func GetTheme(configFlag string) string {
+	// Priority 1: Environment variable
+	if envTheme := os.Getenv("PLAN_THEME"); envTheme != "" {
+		return strings.ToLower(envTheme)
+	}
+
+	// Priority 2: Config file
+	cfg := loadConfig(configFlag)
+	if cfg.Theme != "" {
+		return strings.ToLower(cfg.Theme)
+	}
+
+	// Priority 3: Default
+	return output.DefaultTheme
+}
+
+// GetColorOverrides returns theme role overrides from the config file, keyed by role name
+func GetColorOverrides(configFlag string) map[string]string {
+	return loadConfig(configFlag).ColorOverrides
+}
+
+// DefaultPager pages long output; -R keeps colors
+const DefaultPager = "less -R"
+
+// GetPager resolves the pager command for long output, or "" when paging is disabled
+// Priority: --no-pager flag > PLAN_PAGER env > config file > PAGER env > default (less -R)
+func GetPager(configFlag, noPagerFlag string) string {
+	// Priority 1: Command-line flag
+	if isTruthy(noPagerFlag) {
+		return ""
+	}
+
+	// Priority 2: Environment variable
+	if envPager := os.Getenv("PLAN_PAGER"); envPager != "" {
+		return envPager
+	}
+
+	// Priority 3: Config file
+	cfg := loadConfig(configFlag)
+	if cfg.Pager != "" {
+		return cfg.Pager
+	}
+
+	// Priority 4: Standard PAGER environment variable
+	if envPager := os.Getenv("PAGER"); envPager != "" {
+		return envPager
+	}
+
+	// Priority 5: Default
+	return DefaultPager
+}
+
+// GetPassphrase returns the encrypted journal passphrase from the environment, if set
+// The passphrase is never read from the config file
+func GetPassphrase() string {
+	return os.Getenv("PLAN_PASSPHRASE")
+}
+
+// GetPassphraseCommand resolves the command that prints the encrypted journal passphrase
+// Priority: PLAN_PASSPHRASE_COMMAND env > config file > none
+func GetPassphraseCommand(configFlag string) string {
+	// Priority 1: Environment variable
+	if envCommand := os.Getenv("PLAN_PASSPHRASE_COMMAND"); envCommand != "" {
+		return envCommand
+	}
+
+	// Priority 2: Config file
+	return loadConfig(configFlag).PassphraseCmd
+}
+
+// DefaultServeAddr is where plan serve listens: only this machine can connect
+const DefaultServeAddr = "127.0.0.1:8080"
+
+// GetServeAddr resolves the address plan serve listens on
+// Priority: addrFlag > PLAN_SERVE_ADDR env > config file > default (127.0.0.1:8080)
+func GetServeAddr(configFlag, addrFlag string) string {
+	// Priority 1: Command-line flag
+	if addrFlag != "" {
+		return addrFlag
+	}
+
+	// Priority 2: Environment variable
+	if envAddr := os.Getenv("PLAN_SERVE_ADDR"); envAddr != "" {
+		return envAddr
+	}
+
+	// Priority 3: Config file
+	cfg := loadConfig(configFlag)
+	if cfg.ServeAddr != "" {
+		return cfg.ServeAddr
+	}
+
+	// Priority 4: Default
+	return DefaultServeAddr
+}
+
+// GetServeToken resolves the token plan serve requires from clients
+// Priority: tokenFlag > PLAN_SERVE_TOKEN env > config file > none (no authentication)
+func GetServeToken(configFlag, tokenFlag string) string {
+	// Priority 1: Command-line flag
+	if tokenFlag != "" {
+		return tokenFlag
+	}
+
+	// Priority 2: Environment variable
+	if envToken := os.Getenv("PLAN_SERVE_TOKEN"); envToken != "" {
+		return envToken
+	}
+
+	// Priority 3: Config file
+	return loadConfig(configFlag).ServeToken
+}
+
+// GetServeReadOnly resolves whether plan serve rejects changes to the journal
+// Priority: readOnlyFlag > PLAN_SERVE_READ_ONLY env > config file > default (false)
+func GetServeReadOnly(configFlag, readOnlyFlag string) bool {
+	// Priority 1: Command-line flag
+	if readOnlyFlag != "" {
+		return isTruthy(readOnlyFlag)
+	}
+
+	// Priority 2: Environment variable
+	if envReadOnly := os.Getenv("PLAN_SERVE_READ_ONLY"); envReadOnly != "" {
+		return isTruthy(envReadOnly)
+	}
+
+	// Priority 3: Config file
+	cfg := loadConfig(configFlag)
+	if cfg.ServeReadOnly != "" {
+		return isTruthy(cfg.ServeReadOnly)
+	}
+
+	// Priority 4: Default (changes allowed)
+	return false
+}
+
+// GetServeHosts resolves the extra host names plan serve accepts requests for
+// Priority: hostsFlag > PLAN_SERVE_HOSTS env > config file > none (localhost and IP addresses only)
+func GetServeHosts(configFlag, hostsFlag string) []string {
+	value := hostsFlag
+	if value == "" {
+		value = os.Getenv("PLAN_SERVE_HOSTS")
+	}
+	if value == "" {
+		value = loadConfig(configFlag).ServeHosts
+	}
+
+	var hosts []string
+	for _, host := range strings.Split(value, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
 // isTruthy checks if a string value should be considered true
 // Accepts: "1", "true", "yes", "y" (case-insensitive)
 func isTruthy(value string) bool {
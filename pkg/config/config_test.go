@@ -3,7 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetPlansDirectory(t *testing.T) {
@@ -13,9 +16,6 @@ func TestGetPlansDirectory(t *testing.T) {
 	defer func() {
 		os.Setenv("PLAN_LOCATION", origLocation)
 		os.Setenv("PLAN_CONFIG", origConfig)
-		loadedConfig = nil
-		cachedConfigPath = ""
-		cachedConfigFlag = ""
 	}()
 
 	tests := []struct {
@@ -46,11 +46,6 @@ func TestGetPlansDirectory(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset cached config
-			loadedConfig = nil
-			cachedConfigPath = ""
-			cachedConfigFlag = ""
-
 			// Set env vars
 			if tt.envLocation != "" {
 				os.Setenv("PLAN_LOCATION", tt.envLocation)
@@ -74,9 +69,6 @@ func TestGetEditorCommand(t *testing.T) {
 	defer func() {
 		os.Setenv("PLAN_EDITOR", origEditor)
 		os.Setenv("PLAN_CONFIG", origConfig)
-		loadedConfig = nil
-		cachedConfigPath = ""
-		cachedConfigFlag = ""
 	}()
 
 	tests := []struct {
@@ -89,7 +81,7 @@ func TestGetEditorCommand(t *testing.T) {
 			name:       "flag takes priority",
 			editorFlag: "vim",
 			envEditor:  "emacs",
-			want:       "vim +%line% %file%",
+			want:       `vim "+call cursor(%line%, %column%)" %file%`,
 		},
 		{
 			name:       "env when no flag",
@@ -107,17 +99,12 @@ func TestGetEditorCommand(t *testing.T) {
 			name:       "builtin vim name resolves",
 			editorFlag: "vim",
 			envEditor:  "",
-			want:       "vim +%line% %file%",
+			want:       `vim "+call cursor(%line%, %column%)" %file%`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset cached config
-			loadedConfig = nil
-			cachedConfigPath = ""
-			cachedConfigFlag = ""
-
 			// Set env vars
 			if tt.envEditor != "" {
 				os.Setenv("PLAN_EDITOR", tt.envEditor)
@@ -145,9 +132,6 @@ func TestGetPreamble(t *testing.T) {
 	defer func() {
 		os.Setenv("PLAN_PREAMBLE", origPreamble)
 		os.Setenv("PLAN_CONFIG", origConfig)
-		loadedConfig = nil
-		cachedConfigPath = ""
-		cachedConfigFlag = ""
 	}()
 
 	tests := []struct {
@@ -178,11 +162,6 @@ func TestGetPreamble(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Reset cached config
-			loadedConfig = nil
-			cachedConfigPath = ""
-			cachedConfigFlag = ""
-
 			// Set env vars
 			if tt.envPreamble != "" {
 				os.Setenv("PLAN_PREAMBLE", tt.envPreamble)
@@ -219,9 +198,6 @@ PLAN_LOCATION=/test/location
 	origConfig := os.Getenv("PLAN_CONFIG")
 	defer func() {
 		os.Setenv("PLAN_CONFIG", origConfig)
-		loadedConfig = nil
-		cachedConfigPath = ""
-		cachedConfigFlag = ""
 	}()
 
 	os.Setenv("PLAN_CONFIG", configPath)
@@ -229,20 +205,12 @@ PLAN_LOCATION=/test/location
 	os.Unsetenv("PLAN_EDITOR")
 	os.Unsetenv("PLAN_LOCATION")
 
-	// Reset cached config
-	loadedConfig = nil
-	cachedConfigPath = ""
-
 	// Test preamble from config
 	preamble := GetPreamble("", "")
 	if preamble != "Test preamble from file" {
 		t.Errorf("GetPreamble() from config = %v, want %v", preamble, "Test preamble from file")
 	}
 
-	// Reset for editor test
-	loadedConfig = nil
-	cachedConfigPath = ""
-
 	// Test editor from config
 	editor, err := GetEditorCommand("", "")
 	if err != nil {
@@ -294,3 +262,525 @@ func TestExpandPath(t *testing.T) {
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || filepath.Base(s) == substr || filepath.Dir(s) == substr)
 }
+
+func TestFindProject(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "src", "pkg")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("Failed to create nested dir: %v", err)
+	}
+
+	// No markers inside the temp tree
+	if project := findProject(nested); project != nil && strings.HasPrefix(project.Root, root) {
+		t.Errorf("findProject() found unexpected project at %v", project.Root)
+	}
+
+	// .plans/ directory in root is discovered from a nested directory
+	if err := os.Mkdir(filepath.Join(root, ".plans"), 0755); err != nil {
+		t.Fatalf("Failed to create .plans: %v", err)
+	}
+	project := findProject(nested)
+	if project == nil {
+		t.Fatal("findProject() = nil, want project")
+	}
+	if project.Root != root {
+		t.Errorf("Root = %v, want %v", project.Root, root)
+	}
+	if project.PlansDir != filepath.Join(root, ".plans") {
+		t.Errorf("PlansDir = %v, want %v", project.PlansDir, filepath.Join(root, ".plans"))
+	}
+	if project.ConfigPath != "" {
+		t.Errorf("ConfigPath = %v, want empty", project.ConfigPath)
+	}
+
+	// Nearest marker wins
+	srcConfig := filepath.Join(root, "src", ".plan.config")
+	if err := os.WriteFile(srcConfig, []byte("PLAN_LOCATION=notes\n"), 0644); err != nil {
+		t.Fatalf("Failed to create .plan.config: %v", err)
+	}
+	project = findProject(nested)
+	if project == nil || project.ConfigPath != srcConfig {
+		t.Errorf("findProject() = %+v, want ConfigPath %v", project, srcConfig)
+	}
+}
+
+func TestProjectPrecedence(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("Failed to create nested dir: %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(root, ".plans"), 0755); err != nil {
+		t.Fatalf("Failed to create .plans: %v", err)
+	}
+
+	origGetwd := getwd
+	origLocation := os.Getenv("PLAN_LOCATION")
+	origConfig := os.Getenv("PLAN_CONFIG")
+	defer func() {
+		getwd = origGetwd
+		os.Setenv("PLAN_LOCATION", origLocation)
+		os.Setenv("PLAN_CONFIG", origConfig)
+	}()
+	getwd = func() (string, error) { return nested, nil }
+	os.Unsetenv("PLAN_LOCATION")
+	os.Unsetenv("PLAN_CONFIG")
+
+	// .plans/ directory is used when nothing else is set
+	if got := GetPlansDirectory("", ""); got != filepath.Join(root, ".plans") {
+		t.Errorf("GetPlansDirectory() = %v, want %v", got, filepath.Join(root, ".plans"))
+	}
+
+	// Flag still wins over the project
+	if got := GetPlansDirectory("", "/tmp/flag-plans"); got != "/tmp/flag-plans" {
+		t.Errorf("GetPlansDirectory() with flag = %v, want /tmp/flag-plans", got)
+	}
+
+	// .plan.config becomes the config file, and its relative PLAN_LOCATION resolves against the project root
+	configPath := filepath.Join(root, ".plan.config")
+	if err := os.WriteFile(configPath, []byte("PLAN_LOCATION=journal\n"), 0644); err != nil {
+		t.Fatalf("Failed to create .plan.config: %v", err)
+	}
+	if got := GetConfigPath(""); got != configPath {
+		t.Errorf("GetConfigPath() = %v, want %v", got, configPath)
+	}
+	if got := GetPlansDirectory("", ""); got != filepath.Join(root, "journal") {
+		t.Errorf("GetPlansDirectory() = %v, want %v", got, filepath.Join(root, "journal"))
+	}
+
+	// Environment variable wins over the project config
+	os.Setenv("PLAN_CONFIG", "/tmp/nonexistent-config-file-for-testing-12345")
+	if got := GetConfigPath(""); got != "/tmp/nonexistent-config-file-for-testing-12345" {
+		t.Errorf("GetConfigPath() with env = %v", got)
+	}
+	if got := GetPlansDirectory("", ""); got != filepath.Join(root, ".plans") {
+		t.Errorf("GetPlansDirectory() with env config = %v, want %v", got, filepath.Join(root, ".plans"))
+	}
+
+	// PLAN_LOCATION in an explicit config file wins over the project
+	explicitPath := filepath.Join(t.TempDir(), "explicit.config")
+	if err := os.WriteFile(explicitPath, []byte("PLAN_LOCATION=/tmp/explicit-plans\n"), 0644); err != nil {
+		t.Fatalf("Failed to create config: %v", err)
+	}
+	os.Setenv("PLAN_CONFIG", explicitPath)
+	if got := GetPlansDirectory("", ""); got != "/tmp/explicit-plans" {
+		t.Errorf("GetPlansDirectory() with PLAN_CONFIG = %v, want /tmp/explicit-plans", got)
+	}
+	os.Unsetenv("PLAN_CONFIG")
+	if got := GetPlansDirectory(explicitPath, ""); got != "/tmp/explicit-plans" {
+		t.Errorf("GetPlansDirectory() with --config = %v, want /tmp/explicit-plans", got)
+	}
+}
+
+func TestProjectTrust(t *testing.T) {
+	root := t.TempDir()
+	plansDir := filepath.Join(root, ".plans")
+	if err := os.Mkdir(plansDir, 0755); err != nil {
+		t.Fatalf("Failed to create .plans: %v", err)
+	}
+	// The project can't trust itself
+	projectConfig := "PLAN_HOOK_PRE_EDIT=touch pwned\nPLAN_PAGER=cat\nPLAN_HOOK_TIMEOUT=5\nPLAN_PREAMBLE=Repo\nPLAN_TRUSTED_PROJECT=" + root + "\n"
+	if err := os.WriteFile(filepath.Join(root, ".plan.config"), []byte(projectConfig), 0644); err != nil {
+		t.Fatalf("Failed to create .plan.config: %v", err)
+	}
+
+	origGetwd := getwd
+	defer func() { getwd = origGetwd }()
+	getwd = func() (string, error) { return root, nil }
+	t.Setenv("HOME", t.TempDir())
+	for _, key := range []string{"PLAN_CONFIG", "PLAN_LOCATION", "PLAN_PAGER", "PAGER", "PLAN_HOOKS_DIR", "PLAN_HOOK_TIMEOUT", "PLAN_PREAMBLE"} {
+		t.Setenv(key, "")
+	}
+
+	// Commands and the hooks directory are ignored until the project is trusted; other keys apply
+	if got := GetHookCommands(""); len(got) != 0 {
+		t.Errorf("GetHookCommands() untrusted = %v, want none", got)
+	}
+	if got := GetPager("", ""); got != DefaultPager {
+		t.Errorf("GetPager() untrusted = %q, want %q", got, DefaultPager)
+	}
+	if got := GetHooksDirectory("", plansDir); got != "" {
+		t.Errorf("GetHooksDirectory() untrusted = %q, want none", got)
+	}
+	if got := GetIgnoredProjectKeys(""); !reflect.DeepEqual(got, []string{"PLAN_HOOK_PRE_EDIT", "PLAN_PAGER"}) {
+		t.Errorf("GetIgnoredProjectKeys() = %v", got)
+	}
+	if got := GetHookTimeout(""); got != 5*time.Second {
+		t.Errorf("GetHookTimeout() untrusted = %v, want 5s", got)
+	}
+	if got := GetPreamble("", ""); got != "Repo" {
+		t.Errorf("GetPreamble() untrusted = %q, want Repo", got)
+	}
+
+	project := DiscoverProject()
+	if err := project.Trust(""); err != nil {
+		t.Fatalf("Trust() error = %v", err)
+	}
+	if !project.Trusted("") {
+		t.Fatal("Trusted() = false after Trust()")
+	}
+	if got := GetHookCommands(""); got["pre-edit"] != "touch pwned" {
+		t.Errorf("GetHookCommands() trusted = %v", got)
+	}
+	if got := GetPager("", ""); got != "cat" {
+		t.Errorf("GetPager() trusted = %q, want cat", got)
+	}
+	if got := GetHooksDirectory("", plansDir); got != filepath.Join(plansDir, ".hooks") {
+		t.Errorf("GetHooksDirectory() trusted = %q", got)
+	}
+}
+
+func TestCustomEditors(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".config")
+
+	configContent := `PLAN_CUSTOM_EDITOR_MYVIM=vim -u NONE +%line% %file%
+PLAN_CUSTOM_EDITOR_WRITER_TYPE=gui
+PLAN_CUSTOM_EDITOR_WRITER=writer --open %file%
+PLAN_EDITOR=myvim
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to create test config file: %v", err)
+	}
+
+	origEditor := os.Getenv("PLAN_EDITOR")
+	defer func() {
+		os.Setenv("PLAN_EDITOR", origEditor)
+	}()
+	os.Unsetenv("PLAN_EDITOR")
+
+	editors := GetEditors(configPath)
+	myvim, ok := editors["myvim"]
+	if !ok {
+		t.Fatal("GetEditors() missing custom editor myvim")
+	}
+	if !myvim.Custom || !myvim.Terminal {
+		t.Errorf("myvim = %+v, want custom terminal editor (inherited from vim)", myvim)
+	}
+	if writer := editors["writer"]; writer.Terminal || writer.Command != "writer --open %file%" {
+		t.Errorf("writer = %+v, want gui editor with template", writer)
+	}
+	if _, ok := editors["vscode"]; !ok {
+		t.Error("GetEditors() missing built-in vscode")
+	}
+
+	// Config file selects the custom editor by name
+	got, err := GetEditorCommand(configPath, "")
+	if err != nil {
+		t.Fatalf("GetEditorCommand() error = %v", err)
+	}
+	if got != "vim -u NONE +%line% %file%" {
+		t.Errorf("GetEditorCommand() = %v, want custom template", got)
+	}
+
+	if got := EditorTypeForCommand(configPath, "writer --open %file%"); got != "gui" {
+		t.Errorf("EditorTypeForCommand(writer) = %v, want gui", got)
+	}
+	if got := EditorTypeForCommand(configPath, "hx %file%"); got != "terminal" {
+		t.Errorf("EditorTypeForCommand(hx) = %v, want terminal", got)
+	}
+	if got := EditorTypeForCommand(configPath, "mystery %file%"); got != "auto" {
+		t.Errorf("EditorTypeForCommand(mystery) = %v, want auto", got)
+	}
+}
+
+func TestVisualAndEditorFallback(t *testing.T) {
+	origVars := map[string]string{}
+	for _, key := range []string{"PLAN_EDITOR", "PLAN_CONFIG", "VISUAL", "EDITOR"} {
+		origVars[key] = os.Getenv(key)
+	}
+	defer func() {
+		for key, value := range origVars {
+			os.Setenv(key, value)
+		}
+	}()
+	os.Unsetenv("PLAN_EDITOR")
+	os.Setenv("PLAN_CONFIG", "/tmp/nonexistent-config-file-for-testing-12345")
+
+	tests := []struct {
+		name   string
+		visual string
+		editor string
+		want   string
+	}{
+		{
+			name:   "VISUAL wins over EDITOR",
+			visual: "nano",
+			editor: "vim",
+			want:   "nano +%line%,%column% %file%",
+		},
+		{
+			name:   "EDITOR known binary gets line support",
+			editor: "hx",
+			want:   "hx %file%:%line%:%column%",
+		},
+		{
+			name:   "full path keeps the user's binary",
+			editor: "/usr/local/bin/nvim",
+			want:   `/usr/local/bin/nvim "+call cursor(%line%, %column%)" %file%`,
+		},
+		{
+			name:   "unknown command gets file appended",
+			visual: "code --wait",
+			want:   "code --wait %file%",
+		},
+		{
+			name: "default vim when nothing is set",
+			want: `vim "+call cursor(%line%, %column%)" %file%`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("VISUAL", tt.visual)
+			os.Setenv("EDITOR", tt.editor)
+
+			got, err := GetEditorCommand("", "")
+			if err != nil {
+				t.Fatalf("GetEditorCommand() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GetEditorCommand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetEditorFallbacks(t *testing.T) {
+	origFallback := os.Getenv("PLAN_EDITOR_FALLBACK")
+	defer func() {
+		os.Setenv("PLAN_EDITOR_FALLBACK", origFallback)
+	}()
+
+	os.Unsetenv("PLAN_EDITOR_FALLBACK")
+	got := GetEditorFallbacks("/tmp/nonexistent-config-file-for-testing-12345")
+	if len(got) != len(DefaultEditorFallbacks) || got[0] != BuiltInEditors["vim"].Command {
+		t.Errorf("GetEditorFallbacks() default = %v", got)
+	}
+
+	os.Setenv("PLAN_EDITOR_FALLBACK", "helix, my-editor")
+	got = GetEditorFallbacks("/tmp/nonexistent-config-file-for-testing-12345")
+	want := []string{"hx %file%:%line%:%column%", "my-editor %file%"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("GetEditorFallbacks() = %v, want %v", got, want)
+	}
+}
+
+func TestBuiltInEditorsPlaceColumn(t *testing.T) {
+	// The cursor goes after a seeded prefix, so the default editors need the column
+	for _, name := range []string{"vim", "vi"} {
+		if command := BuiltInEditors[name].Command; !strings.Contains(command, "%column%") {
+			t.Errorf("BuiltInEditors[%q] = %q, want a %%column%% placeholder", name, command)
+		}
+	}
+}
+
+func TestGetWeekStart(t *testing.T) {
+	origWeekStart := os.Getenv("PLAN_WEEK_START")
+	defer func() {
+		os.Setenv("PLAN_WEEK_START", origWeekStart)
+	}()
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".config")
+	if err := os.WriteFile(configPath, []byte("PLAN_WEEK_START=saturday\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		flag    string
+		env     string
+		config  string
+		want    time.Weekday
+		wantErr bool
+	}{
+		{name: "default", config: "/tmp/nonexistent-config-file-for-testing-12345", want: time.Monday},
+		{name: "config file", config: configPath, want: time.Saturday},
+		{name: "env over config", env: "sun", config: configPath, want: time.Sunday},
+		{name: "flag over env", flag: "Tuesday", env: "sun", config: configPath, want: time.Tuesday},
+		{name: "invalid", flag: "someday", config: configPath, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("PLAN_WEEK_START", tt.env)
+
+			got, err := GetWeekStart(tt.config, tt.flag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetWeekStart() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("GetWeekStart() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPager(t *testing.T) {
+	origPlanPager := os.Getenv("PLAN_PAGER")
+	origPager := os.Getenv("PAGER")
+	defer func() {
+		os.Setenv("PLAN_PAGER", origPlanPager)
+		os.Setenv("PAGER", origPager)
+	}()
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".config")
+	if err := os.WriteFile(configPath, []byte("PLAN_PAGER=most\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	noConfig := "/tmp/nonexistent-config-file-for-testing-12345"
+
+	tests := []struct {
+		name     string
+		flag     string
+		planEnv  string
+		pagerEnv string
+		config   string
+		want     string
+	}{
+		{name: "default", config: noConfig, want: DefaultPager},
+		{name: "PAGER env", pagerEnv: "more", config: noConfig, want: "more"},
+		{name: "config over PAGER", pagerEnv: "more", config: configPath, want: "most"},
+		{name: "PLAN_PAGER over config", planEnv: "less", pagerEnv: "more", config: configPath, want: "less"},
+		{name: "no-pager flag", flag: "true", planEnv: "less", config: configPath, want: ""},
+		{name: "no-pager false", flag: "false", config: configPath, want: "most"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("PLAN_PAGER", tt.planEnv)
+			os.Setenv("PAGER", tt.pagerEnv)
+
+			if got := GetPager(tt.config, tt.flag); got != tt.want {
+				t.Errorf("GetPager() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetThemeAndColorOverrides(t *testing.T) {
+	origTheme := os.Getenv("PLAN_THEME")
+	defer func() {
+		os.Setenv("PLAN_THEME", origTheme)
+	}()
+	os.Setenv("PLAN_THEME", "")
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".config")
+	content := "PLAN_THEME=Dark\nPLAN_COLOR_TASK_OPEN=bold 208\nPLAN_COLOR_HEADER=underline\nPLAN_NO_COLOR=false\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if got := GetTheme("/tmp/nonexistent-config-file-for-testing-12345"); got != "minimal" {
+		t.Errorf("GetTheme() default = %q, want minimal", got)
+	}
+	if got := GetTheme(configPath); got != "dark" {
+		t.Errorf("GetTheme() from config = %q, want dark", got)
+	}
+	os.Setenv("PLAN_THEME", "light")
+	if got := GetTheme(configPath); got != "light" {
+		t.Errorf("GetTheme() from env = %q, want light", got)
+	}
+
+	want := map[string]string{"task-open": "bold 208", "header": "underline"}
+	if got := GetColorOverrides(configPath); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetColorOverrides() = %v, want %v", got, want)
+	}
+}
+
+func TestGetPassphraseCommand(t *testing.T) {
+	t.Setenv("PLAN_PASSPHRASE_COMMAND", "")
+	defer func() {
+	}()
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".config")
+	if err := os.WriteFile(configPath, []byte("PLAN_PASSPHRASE_COMMAND=pass show plan\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if got := GetPassphraseCommand(configPath); got != "pass show plan" {
+		t.Errorf("GetPassphraseCommand() = %q, want the config command", got)
+	}
+
+	t.Setenv("PLAN_PASSPHRASE_COMMAND", "cat ~/.plan-pass")
+	if got := GetPassphraseCommand(configPath); got != "cat ~/.plan-pass" {
+		t.Errorf("GetPassphraseCommand() = %q, want the env command", got)
+	}
+}
+
+func TestServeSettings(t *testing.T) {
+	t.Setenv("PLAN_SERVE_ADDR", "")
+	t.Setenv("PLAN_SERVE_TOKEN", "")
+	t.Setenv("PLAN_SERVE_READ_ONLY", "")
+	t.Setenv("PLAN_SERVE_HOSTS", "")
+	defer func() {
+	}()
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".config")
+	content := "PLAN_SERVE_ADDR=0.0.0.0:9000\nPLAN_SERVE_TOKEN=from-config\nPLAN_SERVE_READ_ONLY=yes\nPLAN_SERVE_HOSTS=journal.lan, laptop.local\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	noConfig := "/tmp/nonexistent-config-file-for-testing-12345"
+
+	if got := GetServeAddr(noConfig, ""); got != DefaultServeAddr {
+		t.Errorf("GetServeAddr() default = %q, want %q", got, DefaultServeAddr)
+	}
+	if got := GetServeToken(noConfig, ""); got != "" {
+		t.Errorf("GetServeToken() default = %q, want none", got)
+	}
+	if GetServeReadOnly(noConfig, "") {
+		t.Error("GetServeReadOnly() default = true, want false")
+	}
+	if got := GetServeHosts(noConfig, ""); got != nil {
+		t.Errorf("GetServeHosts() default = %q, want none", got)
+	}
+
+	if got := GetServeAddr(configPath, ""); got != "0.0.0.0:9000" {
+		t.Errorf("GetServeAddr() from config = %q", got)
+	}
+	if got := GetServeToken(configPath, ""); got != "from-config" {
+		t.Errorf("GetServeToken() from config = %q", got)
+	}
+	if !GetServeReadOnly(configPath, "") {
+		t.Error("GetServeReadOnly() from config = false, want true")
+	}
+	if got := GetServeHosts(configPath, ""); !reflect.DeepEqual(got, []string{"journal.lan", "laptop.local"}) {
+		t.Errorf("GetServeHosts() from config = %q", got)
+	}
+
+	t.Setenv("PLAN_SERVE_ADDR", ":8081")
+	t.Setenv("PLAN_SERVE_TOKEN", "from-env")
+	t.Setenv("PLAN_SERVE_READ_ONLY", "false")
+	if got := GetServeAddr(configPath, ""); got != ":8081" {
+		t.Errorf("GetServeAddr() from env = %q", got)
+	}
+	if got := GetServeToken(configPath, ""); got != "from-env" {
+		t.Errorf("GetServeToken() from env = %q", got)
+	}
+	if GetServeReadOnly(configPath, "") {
+		t.Error("GetServeReadOnly() from env = true, want false")
+	}
+	t.Setenv("PLAN_SERVE_HOSTS", "nas.lan")
+	if got := GetServeHosts(configPath, ""); !reflect.DeepEqual(got, []string{"nas.lan"}) {
+		t.Errorf("GetServeHosts() from env = %q", got)
+	}
+
+	if got := GetServeAddr(configPath, "localhost:1234"); got != "localhost:1234" {
+		t.Errorf("GetServeAddr() from flag = %q", got)
+	}
+	if got := GetServeToken(configPath, "from-flag"); got != "from-flag" {
+		t.Errorf("GetServeToken() from flag = %q", got)
+	}
+	if !GetServeReadOnly(configPath, "true") {
+		t.Error("GetServeReadOnly() from flag = false, want true")
+	}
+	if got := GetServeHosts(configPath, "pi.lan"); !reflect.DeepEqual(got, []string{"pi.lan"}) {
+		t.Errorf("GetServeHosts() from flag = %q", got)
+	}
+}
@@ -1,19 +1,224 @@
 package config
 
+import (
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
 // EditorTemplate defines an editor with its command template
 type EditorTemplate struct {
-	Name    string
-	Command string // Template with %file%, %line%, %column%
+	Name     string
+	Command  string // Template with %file%, %line%, %column%
+	Terminal bool   // Runs inside the terminal (CLI waits for it to exit)
+	Custom   bool   // Registered by the user in the config file
 }
 
 // BuiltInEditors contains predefined editor configurations
+// This is the single source of truth for editor templates and terminal/GUI detection
 var BuiltInEditors = map[string]EditorTemplate{
+	// Terminal editors
+	"vim": {
+		Name:     "Vim",
+		Command:  `vim "+call cursor(%line%, %column%)" %file%`,
+		Terminal: true,
+	},
+	"vi": {
+		Name:     "Vi",
+		Command:  `vi +%line% "+normal! %column%|" %file%`,
+		Terminal: true,
+	},
+	"neovim": {
+		Name:     "Neovim",
+		Command:  `nvim "+call cursor(%line%, %column%)" %file%`,
+		Terminal: true,
+	},
+	"nano": {
+		Name:     "GNU nano",
+		Command:  "nano +%line%,%column% %file%",
+		Terminal: true,
+	},
+	"emacsclient": {
+		Name:     "Emacs client (terminal frame)",
+		Command:  "emacsclient -t +%line%:%column% %file%",
+		Terminal: true,
+	},
+	"helix": {
+		Name:     "Helix",
+		Command:  "hx %file%:%line%:%column%",
+		Terminal: true,
+	},
+	"kakoune": {
+		Name:     "Kakoune",
+		Command:  "kak +%line%:%column% %file%",
+		Terminal: true,
+	},
+	"micro": {
+		Name:     "micro",
+		Command:  "micro +%line%:%column% %file%",
+		Terminal: true,
+	},
+	"joe": {
+		Name:     "Joe's Own Editor",
+		Command:  "joe +%line% %file%",
+		Terminal: true,
+	},
+	"jed": {
+		Name:     "JED",
+		Command:  "jed %file% -g %line%",
+		Terminal: true,
+	},
+	"mcedit": {
+		Name:     "Midnight Commander editor",
+		Command:  "mcedit %file%:%line%",
+		Terminal: true,
+	},
+
+	// GUI editors
 	"vscode": {
 		Name:    "Visual Studio Code",
 		Command: "code --goto %file%:%line%:%column%",
 	},
-	"vim": {
-		Name:    "Vim",
-		Command: "vim +%line% %file%",
+	"emacs": {
+		Name:    "GNU Emacs",
+		Command: "emacs +%line%:%column% %file%",
+	},
+	"sublime": {
+		Name:    "Sublime Text",
+		Command: "subl %file%:%line%:%column%",
+	},
+	"zed": {
+		Name:    "Zed",
+		Command: "zed %file%:%line%:%column%",
+	},
+	"idea": {
+		Name:    "IntelliJ IDEA",
+		Command: "idea --line %line% --column %column% %file%",
+	},
+	"goland": {
+		Name:    "GoLand",
+		Command: "goland --line %line% --column %column% %file%",
+	},
+	"pycharm": {
+		Name:    "PyCharm",
+		Command: "pycharm --line %line% --column %column% %file%",
+	},
+	"webstorm": {
+		Name:    "WebStorm",
+		Command: "webstorm --line %line% --column %column% %file%",
+	},
+	"clion": {
+		Name:    "CLion",
+		Command: "clion --line %line% --column %column% %file%",
 	},
+	"gedit": {
+		Name:    "gedit",
+		Command: "gedit +%line%:%column% %file%",
+	},
+	"kate": {
+		Name:    "Kate",
+		Command: "kate --line %line% --column %column% %file%",
+	},
+}
+
+// Binary returns the executable name from the editor's command template
+func (e EditorTemplate) Binary() string {
+	return CommandBinary(e.Command)
+}
+
+// Installed reports whether the editor's executable is found on PATH
+func (e EditorTemplate) Installed() bool {
+	_, err := exec.LookPath(e.Binary())
+	return err == nil
+}
+
+// CommandBinary extracts the executable from a command template
+func CommandBinary(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.Trim(fields[0], `"'`)
+}
+
+// LookupEditorByBinary finds a built-in editor whose executable matches binary
+// The binary may be a bare name or a full path
+func LookupEditorByBinary(binary string) (EditorTemplate, bool) {
+	base := filepath.Base(binary)
+	for _, tmpl := range BuiltInEditors {
+		if tmpl.Binary() == base {
+			return tmpl, true
+		}
+	}
+	return EditorTemplate{}, false
+}
+
+// GetEditors returns all known editors: built-ins plus editors registered in the config file
+// Custom editors override built-ins with the same name
+func GetEditors(configFlag string) map[string]EditorTemplate {
+	editors := make(map[string]EditorTemplate, len(BuiltInEditors))
+	for name, tmpl := range BuiltInEditors {
+		editors[name] = tmpl
+	}
+
+	cfg := loadConfig(configFlag)
+	for name, tmpl := range cfg.CustomEditors {
+		switch cfg.CustomEditorTypes[name] {
+		case "terminal":
+			tmpl.Terminal = true
+		case "gui":
+			tmpl.Terminal = false
+		default:
+			// No declared type, inherit from a built-in with the same binary
+			if builtIn, ok := LookupEditorByBinary(tmpl.Binary()); ok {
+				tmpl.Terminal = builtIn.Terminal
+			}
+		}
+		editors[name] = tmpl
+	}
+
+	return editors
+}
+
+// EditorNames returns the sorted names of all known editors
+func EditorNames(editors map[string]EditorTemplate) []string {
+	names := make([]string, 0, len(editors))
+	for name := range editors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EditorTypeForCommand returns "terminal" or "gui" when the command's binary belongs
+// to a known editor (custom editors first), otherwise "auto"
+func EditorTypeForCommand(configFlag, command string) string {
+	binary := filepath.Base(CommandBinary(command))
+	if binary == "" {
+		return "auto"
+	}
+
+	cfg := loadConfig(configFlag)
+	for _, name := range EditorNames(cfg.CustomEditors) {
+		if cfg.CustomEditors[name].Binary() != binary {
+			continue
+		}
+		if editorType := cfg.CustomEditorTypes[name]; editorType != "auto" && editorType != "" {
+			return editorType
+		}
+	}
+
+	if tmpl, ok := LookupEditorByBinary(binary); ok {
+		return editorTypeName(tmpl.Terminal)
+	}
+	return "auto"
+}
+
+// editorTypeName maps the terminal flag to an editor type name
+func editorTypeName(terminal bool) string {
+	if terminal {
+		return "terminal"
+	}
+	return "gui"
 }
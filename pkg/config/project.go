@@ -0,0 +1,129 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	// ProjectConfigName is the project-local config file name
+	ProjectConfigName = ".plan.config"
+	// ProjectPlansDirName is the project-local plans directory name
+	ProjectPlansDirName = ".plans"
+)
+
+// Project describes a project-local journal found by walking up from the working directory
+type Project struct {
+	Root       string // Directory where the markers were found
+	ConfigPath string // Path to .plan.config (empty if not present)
+	PlansDir   string // Path to .plans/ (empty if not present)
+}
+
+// getwd is the starting point for project discovery (overridable in tests)
+var getwd = os.Getwd
+
+// DiscoverProject walks up from the working directory looking for a
+// .plan.config file or a .plans/ directory, the same way git finds .git.
+// Returns nil if no project-local journal is found.
+func DiscoverProject() *Project {
+	dir, err := getwd()
+	if err != nil {
+		return nil
+	}
+	return findProject(dir)
+}
+
+// findProject walks up from start until it finds a directory containing a project marker
+func findProject(start string) *Project {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return nil
+	}
+
+	for {
+		project := &Project{Root: dir}
+
+		configPath := filepath.Join(dir, ProjectConfigName)
+		if info, err := os.Stat(configPath); err == nil && !info.IsDir() {
+			project.ConfigPath = configPath
+		}
+
+		plansDir := filepath.Join(dir, ProjectPlansDirName)
+		if info, err := os.Stat(plansDir); err == nil && info.IsDir() {
+			project.PlansDir = plansDir
+		}
+
+		if project.ConfigPath != "" || project.PlansDir != "" {
+			return project
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+}
+
+// ProjectConfigFile returns the config file a project provides, if any
+// A .plan.config file wins over a .config file inside .plans/
+func (p *Project) ProjectConfigFile() string {
+	if p.ConfigPath != "" {
+		return p.ConfigPath
+	}
+	if p.PlansDir != "" {
+		nested := filepath.Join(p.PlansDir, ".config")
+		if info, err := os.Stat(nested); err == nil && !info.IsDir() {
+			return nested
+		}
+	}
+	return ""
+}
+
+// Trusted reports whether the user trusted this project with plan trust, like git's
+// safe.directory. Only a trusted project's config file may set commands (editors, hooks,
+// the pager, the passphrase command) and only its hooks directory is used. The list of
+// trusted projects is read from the user's own config file, never from a project's
+func (p *Project) Trusted(configFlag string) bool {
+	cfg := readConfigFile(UserConfigPath(configFlag), false)
+	return slices.ContainsFunc(cfg.TrustedProjects, func(dir string) bool {
+		return filepath.Clean(expandPath(dir)) == p.Root
+	})
+}
+
+// Contains reports whether path is the project root or inside it
+func (p *Project) Contains(path string) bool {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(p.Root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Trust adds the project to the trusted projects in the user's config file
+func (p *Project) Trust(configFlag string) error {
+	configPath := UserConfigPath(configFlag)
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	// Start on a new line if the file doesn't end with one
+	prefix := ""
+	if data, err := os.ReadFile(configPath); err == nil && len(data) > 0 && data[len(data)-1] != '\n' {
+		prefix = "\n"
+	}
+
+	file, err := os.OpenFile(configPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	if _, err := fmt.Fprintf(file, "%sPLAN_TRUSTED_PROJECT=%s\n", prefix, p.Root); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return file.Close()
+}
@@ -2,6 +2,7 @@ package dateutil
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -89,3 +90,57 @@ func CompareDates(date1, date2 string) int {
 	}
 	return 0
 }
+
+// FilterRange returns the first and last day covered by a YYYY or YYYY-MM filter
+func FilterRange(filter string) (time.Time, time.Time, error) {
+	if len(filter) == 4 {
+		start, err := time.Parse("2006", filter)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid year format: %s (expected YYYY)", filter)
+		}
+		return start, start.AddDate(1, 0, -1), nil
+	}
+
+	start, err := time.Parse("2006-01", filter)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid filter format: %s (expected YYYY or YYYY-MM)", filter)
+	}
+	return start, start.AddDate(0, 1, -1), nil
+}
+
+// IsWeekday reports whether t falls on Monday through Friday
+func IsWeekday(t time.Time) bool {
+	return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday
+}
+
+// ParseWeekday parses a weekday name or abbreviation (e.g. "monday", "Mon")
+func ParseWeekday(name string) (time.Weekday, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		full := strings.ToLower(d.String())
+		if name == full || (len(name) >= 2 && strings.HasPrefix(full, name)) {
+			return d, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("invalid weekday: %s", name)
+}
+
+// MonthGrid lays out a month as calendar weeks starting on weekStart
+// Each week has 7 day numbers; 0 marks a cell outside the month
+func MonthGrid(year int, month time.Month, weekStart time.Weekday) [][7]int {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	daysInMonth := first.AddDate(0, 1, -1).Day()
+	offset := (int(first.Weekday()) - int(weekStart) + 7) % 7
+
+	var weeks [][7]int
+	var week [7]int
+	for day := 1; day <= daysInMonth; day++ {
+		cell := (offset + day - 1) % 7
+		week[cell] = day
+		if cell == 6 || day == daysInMonth {
+			weeks = append(weeks, week)
+			week = [7]int{}
+		}
+	}
+	return weeks
+}
@@ -198,3 +198,80 @@ func TestCompareDates(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterRange(t *testing.T) {
+	tests := []struct {
+		filter    string
+		wantStart string
+		wantEnd   string
+		wantErr   bool
+	}{
+		{filter: "2026", wantStart: "2026-01-01", wantEnd: "2026-12-31"},
+		{filter: "2026-02", wantStart: "2026-02-01", wantEnd: "2026-02-28"},
+		{filter: "2028-02", wantStart: "2028-02-01", wantEnd: "2028-02-29"},
+		{filter: "20xx", wantErr: true},
+		{filter: "2026-13", wantErr: true},
+		{filter: "2026-02-13", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			start, end, err := FilterRange(tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FilterRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if FormatDate(start) != tt.wantStart || FormatDate(end) != tt.wantEnd {
+				t.Errorf("FilterRange() = %s..%s, want %s..%s", FormatDate(start), FormatDate(end), tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestParseWeekday(t *testing.T) {
+	tests := map[string]time.Weekday{
+		"monday":  time.Monday,
+		"Sunday":  time.Sunday,
+		"sat":     time.Saturday,
+		" Tu ":    time.Tuesday,
+		"th":      time.Thursday,
+		"FRIDAY":  time.Friday,
+		"wednesd": time.Wednesday,
+	}
+	for name, want := range tests {
+		got, err := ParseWeekday(name)
+		if err != nil || got != want {
+			t.Errorf("ParseWeekday(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+
+	for _, name := range []string{"", "s", "t", "funday"} {
+		if _, err := ParseWeekday(name); err == nil {
+			t.Errorf("ParseWeekday(%q) expected error", name)
+		}
+	}
+}
+
+func TestMonthGrid(t *testing.T) {
+	// February 2026 starts on a Sunday and has 28 days
+	sundayFirst := MonthGrid(2026, time.February, time.Sunday)
+	if len(sundayFirst) != 4 {
+		t.Fatalf("MonthGrid() returned %d weeks, want 4", len(sundayFirst))
+	}
+	if sundayFirst[0] != [7]int{1, 2, 3, 4, 5, 6, 7} || sundayFirst[3][6] != 28 {
+		t.Errorf("MonthGrid() Sunday start = %v", sundayFirst)
+	}
+
+	mondayFirst := MonthGrid(2026, time.February, time.Monday)
+	if len(mondayFirst) != 5 {
+		t.Fatalf("MonthGrid() returned %d weeks, want 5", len(mondayFirst))
+	}
+	if mondayFirst[0] != [7]int{0, 0, 0, 0, 0, 0, 1} {
+		t.Errorf("MonthGrid() first week = %v", mondayFirst[0])
+	}
+	if mondayFirst[4] != [7]int{23, 24, 25, 26, 27, 28, 0} {
+		t.Errorf("MonthGrid() last week = %v", mondayFirst[4])
+	}
+}
@@ -5,39 +5,30 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+
+	"github.com/abyss/plan-journal-cli/pkg/config"
 )
 
-// LaunchEditor launches an editor with the given template, file, line, and column
-// Template placeholders: %file%, %line%, %column%
+// LaunchEditor launches an editor with the given template and target
+// Template placeholders: %file%, %line%, %column%, %date%, %month%, %plansdir%,
+// %prevfile%, %header_line% (see Target), plus $VAR and ${VAR} environment variables
 // editorType can be "terminal", "gui", or "auto"
-func LaunchEditor(template, filePath string, line, column int, editorType string) error {
-	// Substitute placeholders
-	cmd := strings.ReplaceAll(template, "%file%", filePath)
-	cmd = strings.ReplaceAll(cmd, "%line%", fmt.Sprintf("%d", line))
-	cmd = strings.ReplaceAll(cmd, "%column%", fmt.Sprintf("%d", column))
-
-	// Parse command into parts
-	parts := parseCommand(cmd)
+func LaunchEditor(template string, target Target, editorType string) error {
+	// Split into words first so substituted values (e.g. paths with spaces) stay one argument
+	parts := expandTemplate(template, target)
 	if len(parts) == 0 {
 		return fmt.Errorf("invalid value for editor: empty")
 	}
 
+	// Verify the editor binary exists before launching
+	if _, err := exec.LookPath(parts[0]); err != nil {
+		return fmt.Errorf("editor not found: %s is not installed or not on PATH", parts[0])
+	}
+
 	// Execute command
 	command := exec.Command(parts[0], parts[1:]...)
 
-	// Determine if this is a terminal editor
-	isTerminal := false
-	switch editorType {
-	case "terminal":
-		isTerminal = true
-	case "gui":
-		isTerminal = false
-	case "auto":
-		// Auto-detect based on editor binary name
-		isTerminal = isTerminalEditor(parts[0])
-	}
-
-	if isTerminal {
+	if isTerminal(parts[0], editorType) {
 		// For terminal editors, attach to stdin/stdout/stderr and wait
 		command.Stdin = os.Stdin
 		command.Stdout = os.Stdout
@@ -45,70 +36,88 @@ func LaunchEditor(template, filePath string, line, column int, editorType string
 		return command.Run()
 	}
 
+	if hasWaitFlag(parts[1:]) {
+		// GUI editor asked to block (e.g. code --wait), wait for the window to close
+		command.Stdout = os.Stdout
+		command.Stderr = os.Stderr
+		return command.Run()
+	}
+
 	// For GUI editors, launch without waiting
 	return command.Start()
 }
 
-// isTerminalEditor checks if the editor binary is a known terminal editor
-func isTerminalEditor(editorBinary string) bool {
-	// Do you use a terminal editor not listed here? Open an issue or PR!
-	terminalEditors := []string{
-		"vim", "vi", "nvim", "neovim",
-		"nano",
-		"micro", "helix", "hx",
-		"joe", "jed", "mcedit",
+// WaitsForExit reports whether LaunchEditor blocks until the user is done editing
+// True for terminal editors and GUI editors launched with a wait flag
+func WaitsForExit(template, editorType string) bool {
+	parts := parseCommand(template)
+	if len(parts) == 0 {
+		return false
+	}
+	return isTerminal(parts[0], editorType) || hasWaitFlag(parts[1:])
+}
+
+// isTerminal resolves the editor type for a binary
+func isTerminal(editorBinary, editorType string) bool {
+	switch editorType {
+	case "terminal":
+		return true
+	case "gui":
+		return false
+	default:
+		// Auto-detect based on editor binary name
+		return isTerminalEditor(editorBinary)
 	}
+}
 
-	for _, name := range terminalEditors {
-		if strings.HasSuffix(editorBinary, name) || strings.Contains(editorBinary, "/"+name) {
+// hasWaitFlag checks for the flags GUI editors use to block until the file is closed
+func hasWaitFlag(args []string) bool {
+	for _, arg := range args {
+		switch arg {
+		case "--wait", "-w", "--block":
 			return true
 		}
 	}
-
 	return false
 }
 
-// parseCommand parses a command string into parts, handling quoted arguments
-func parseCommand(cmdStr string) []string {
-	var parts []string
-	var current strings.Builder
-	inQuotes := false
-	quoteChar := rune(0)
-
-	for _, char := range cmdStr {
-		switch {
-		case char == '"' || char == '\'':
-			if inQuotes {
-				if char == quoteChar {
-					// End of quoted section
-					inQuotes = false
-					quoteChar = 0
-				} else {
-					// Different quote char inside quotes
-					current.WriteRune(char)
-				}
-			} else {
-				// Start of quoted section
-				inQuotes = true
-				quoteChar = char
-			}
-
-		case char == ' ' && !inQuotes:
-			// Space outside quotes - end of argument
-			if current.Len() > 0 {
-				parts = append(parts, current.String())
-				current.Reset()
-			}
-
-		default:
-			current.WriteRune(char)
+// IsAvailable reports whether the binary of an editor template is found on PATH
+func IsAvailable(template string) bool {
+	parts := parseCommand(template)
+	if len(parts) == 0 {
+		return false
+	}
+	_, err := exec.LookPath(parts[0])
+	return err == nil
+}
+
+// SelectAvailable returns the first template whose binary is installed
+// The preferred template is tried first, then each fallback in order
+func SelectAvailable(preferred string, fallbacks []string) (string, error) {
+	if IsAvailable(preferred) {
+		return preferred, nil
+	}
+
+	for _, fallback := range fallbacks {
+		if IsAvailable(fallback) {
+			return fallback, nil
 		}
 	}
 
-	// Add final part
-	if current.Len() > 0 {
-		parts = append(parts, current.String())
+	tried := append([]string{preferred}, fallbacks...)
+	binaries := make([]string, 0, len(tried))
+	for _, template := range tried {
+		if parts := parseCommand(template); len(parts) > 0 {
+			binaries = append(binaries, parts[0])
+		}
 	}
+	return "", fmt.Errorf("no editor found on PATH (tried: %s)", strings.Join(binaries, ", "))
+}
 
-	return parts
+// isTerminalEditor checks if the editor binary is a known terminal editor
+// Detection uses the built-in editor registry in the config package
+func isTerminalEditor(editorBinary string) bool {
+	// Do you use a terminal editor not listed here? Add it to config.BuiltInEditors!
+	tmpl, ok := config.LookupEditorByBinary(editorBinary)
+	return ok && tmpl.Terminal
 }
@@ -52,3 +52,46 @@ func TestParseCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestIsTerminalEditor(t *testing.T) {
+	tests := []struct {
+		binary string
+		want   bool
+	}{
+		{binary: "vim", want: true},
+		{binary: "/usr/bin/nvim", want: true},
+		{binary: "hx", want: true},
+		{binary: "kak", want: true},
+		{binary: "code", want: false},
+		{binary: "subl", want: false},
+		{binary: "unknown-editor", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.binary, func(t *testing.T) {
+			if got := isTerminalEditor(tt.binary); got != tt.want {
+				t.Errorf("isTerminalEditor(%q) = %v, want %v", tt.binary, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectAvailable(t *testing.T) {
+	// "sh" is always available on the systems we test on
+	got, err := SelectAvailable("definitely-not-an-editor-12345 %file%", []string{"also-missing-12345 %file%", "sh %file%"})
+	if err != nil {
+		t.Fatalf("SelectAvailable() error = %v", err)
+	}
+	if got != "sh %file%" {
+		t.Errorf("SelectAvailable() = %v, want %v", got, "sh %file%")
+	}
+
+	got, err = SelectAvailable("sh -c true", nil)
+	if err != nil || got != "sh -c true" {
+		t.Errorf("SelectAvailable() = %v, %v, want preferred template", got, err)
+	}
+
+	if _, err := SelectAvailable("definitely-not-an-editor-12345", []string{"also-missing-12345"}); err == nil {
+		t.Error("SelectAvailable() error = nil, want error when nothing is installed")
+	}
+}
@@ -0,0 +1,172 @@
+package editor
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Target describes what the editor should open; each field fills a template placeholder
+type Target struct {
+	File       string // %file%: the plan file
+	Line       int    // %line%: cursor line (1-based)
+	Column     int    // %column%: cursor column (1-based)
+	Date       string // %date%: YYYY-MM-DD
+	Month      string // %month%: YYYY-MM
+	PlansDir   string // %plansdir%: the plans directory
+	PrevFile   string // %prevfile%: the plan file containing the previous day
+	HeaderLine int    // %header_line%: line of the date header
+}
+
+// placeholders maps each template placeholder to its value for this target
+func (t Target) placeholders() map[string]string {
+	return map[string]string{
+		"%file%":        t.File,
+		"%line%":        strconv.Itoa(t.Line),
+		"%column%":      strconv.Itoa(t.Column),
+		"%date%":        t.Date,
+		"%month%":       t.Month,
+		"%plansdir%":    t.PlansDir,
+		"%prevfile%":    t.PrevFile,
+		"%header_line%": strconv.Itoa(t.HeaderLine),
+	}
+}
+
+// expandTemplate splits a template into arguments and substitutes placeholders in each one
+// A word left empty by its placeholders (e.g. %prevfile% when there is no previous file
+// to show) is dropped, so the editor isn't given an empty argument
+func expandTemplate(template string, target Target) []string {
+	replacements := make([]string, 0, 16)
+	for placeholder, value := range target.placeholders() {
+		replacements = append(replacements, placeholder, value)
+	}
+	replacer := strings.NewReplacer(replacements...)
+
+	var parts []string
+	for _, part := range parseCommand(template) {
+		expanded := replacer.Replace(part)
+		if expanded == "" && part != "" {
+			continue
+		}
+		parts = append(parts, expanded)
+	}
+	return parts
+}
+
+// parseCommand splits a command string into words using shell-like rules:
+//   - single quotes keep their content literally
+//   - double quotes allow \" \\ \$ escapes and variable expansion
+//   - outside quotes, a backslash escapes a space, quote, backslash or $
+//     (other backslashes are kept, so Windows paths work unquoted)
+//   - $VAR and ${VAR} expand from the environment outside single quotes
+func parseCommand(cmdStr string) []string {
+	return splitWords(cmdStr, os.Getenv)
+}
+
+// splitWords implements parseCommand with an injectable variable lookup
+func splitWords(cmdStr string, getenv func(string) string) []string {
+	var parts []string
+	var current strings.Builder
+	inWord := false // Tracks quoted empty strings ("") as words
+	runes := []rune(cmdStr)
+
+	for i := 0; i < len(runes); i++ {
+		char := runes[i]
+		switch {
+		case char == ' ' || char == '\t':
+			// Whitespace outside quotes - end of argument
+			if inWord {
+				parts = append(parts, current.String())
+				current.Reset()
+				inWord = false
+			}
+
+		case char == '\'':
+			// Single quotes: everything literal until the closing quote
+			inWord = true
+			for i++; i < len(runes) && runes[i] != '\''; i++ {
+				current.WriteRune(runes[i])
+			}
+
+		case char == '"':
+			// Double quotes: escapes and variable expansion
+			inWord = true
+			for i++; i < len(runes) && runes[i] != '"'; i++ {
+				switch {
+				case runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune(`"\$`+"`", runes[i+1]):
+					i++
+					current.WriteRune(runes[i])
+				case runes[i] == '$':
+					i = expandVariable(runes, i, &current, getenv)
+				default:
+					current.WriteRune(runes[i])
+				}
+			}
+
+		case char == '\\' && i+1 < len(runes) && strings.ContainsRune(" \t'\"\\$", runes[i+1]):
+			inWord = true
+			i++
+			current.WriteRune(runes[i])
+
+		case char == '$':
+			inWord = true
+			i = expandVariable(runes, i, &current, getenv)
+
+		default:
+			inWord = true
+			current.WriteRune(char)
+		}
+	}
+
+	// Add final part
+	if inWord {
+		parts = append(parts, current.String())
+	}
+
+	return parts
+}
+
+// expandVariable expands $NAME or ${NAME} starting at runes[start] == '$'
+// Writes the value (or a literal '$' if no name follows) and returns the index of the last consumed rune
+func expandVariable(runes []rune, start int, out *strings.Builder, getenv func(string) string) int {
+	i := start + 1
+
+	// ${NAME}
+	if i < len(runes) && runes[i] == '{' {
+		end := i + 1
+		for end < len(runes) && runes[end] != '}' {
+			end++
+		}
+		if end < len(runes) {
+			out.WriteString(getenv(string(runes[i+1 : end])))
+			return end
+		}
+		// Unterminated, keep literally
+		out.WriteRune('$')
+		return start
+	}
+
+	// $NAME
+	end := i
+	for end < len(runes) && isVariableRune(runes[end], end == i) {
+		end++
+	}
+	if end == i {
+		out.WriteRune('$')
+		return start
+	}
+	out.WriteString(getenv(string(runes[i:end])))
+	return end - 1
+}
+
+// isVariableRune reports whether r can appear in a variable name at this position
+func isVariableRune(r rune, first bool) bool {
+	switch {
+	case r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+		return true
+	case r >= '0' && r <= '9':
+		return !first
+	default:
+		return false
+	}
+}
@@ -0,0 +1,124 @@
+package editor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	env := map[string]string{"HOME": "/home/me", "EDITOR_OPTS": "-u NONE"}
+	getenv := func(key string) string { return env[key] }
+
+	tests := []struct {
+		name   string
+		cmdStr string
+		want   []string
+	}{
+		{
+			name:   "backslash escapes space",
+			cmdStr: `vim my\ file.plan`,
+			want:   []string{"vim", "my file.plan"},
+		},
+		{
+			name:   "backslash escapes quote",
+			cmdStr: `echo it\'s`,
+			want:   []string{"echo", "it's"},
+		},
+		{
+			name:   "windows path backslashes are kept",
+			cmdStr: `C:\Tools\edit.exe %file%`,
+			want:   []string{`C:\Tools\edit.exe`, "%file%"},
+		},
+		{
+			name:   "escapes inside double quotes",
+			cmdStr: `nvim -c "echo \"hi\" \$HOME"`,
+			want:   []string{"nvim", "-c", `echo "hi" $HOME`},
+		},
+		{
+			name:   "variable expansion",
+			cmdStr: `$HOME/bin/vim ${HOME}/x`,
+			want:   []string{"/home/me/bin/vim", "/home/me/x"},
+		},
+		{
+			name:   "variable in double quotes stays one word",
+			cmdStr: `vim "$EDITOR_OPTS" %file%`,
+			want:   []string{"vim", "-u NONE", "%file%"},
+		},
+		{
+			name:   "no expansion in single quotes",
+			cmdStr: `echo '$HOME'`,
+			want:   []string{"echo", "$HOME"},
+		},
+		{
+			name:   "lone dollar is literal",
+			cmdStr: `echo $ 5$`,
+			want:   []string{"echo", "$", "5$"},
+		},
+		{
+			name:   "empty quoted argument",
+			cmdStr: `cmd "" x`,
+			want:   []string{"cmd", "", "x"},
+		},
+		{
+			name:   "tabs separate words",
+			cmdStr: "vim\t+10\tfile.txt",
+			want:   []string{"vim", "+10", "file.txt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitWords(tt.cmdStr, getenv)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitWords() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandTemplate(t *testing.T) {
+	target := Target{
+		File:       "/home/me/my plans/2026-03.plan",
+		Line:       12,
+		Column:     3,
+		Date:       "2026-03-01",
+		Month:      "2026-03",
+		PlansDir:   "/home/me/my plans",
+		PrevFile:   "/home/me/my plans/2026-02.plan",
+		HeaderLine: 10,
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     []string
+	}{
+		{
+			name:     "paths with spaces stay one argument",
+			template: "vim -O %prevfile% +%line% %file%",
+			want:     []string{"vim", "-O", "/home/me/my plans/2026-02.plan", "+12", "/home/me/my plans/2026-03.plan"},
+		},
+		{
+			name:     "all placeholders",
+			template: "ed %date% %month% %plansdir% %header_line% %file%:%line%:%column%",
+			want:     []string{"ed", "2026-03-01", "2026-03", "/home/me/my plans", "10", "/home/me/my plans/2026-03.plan:12:3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expandTemplate(tt.template, target)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Without a previous file (encrypted journals), its word is dropped; explicit "" stays
+	target.PrevFile = ""
+	got := expandTemplate(`vim -O %prevfile% +%line% "" %file%`, target)
+	want := []string{"vim", "-O", "+12", "", "/home/me/my plans/2026-03.plan"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expandTemplate() without a previous file = %q, want %q", got, want)
+	}
+}
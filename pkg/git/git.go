@@ -0,0 +1,118 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ConflictError is returned by Sync when the rebase stops on conflicting changes
+type ConflictError struct {
+	Files []string // Paths (relative to the repository root) with conflicts
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("sync stopped on conflicts in: %s", strings.Join(e.Files, ", "))
+}
+
+// Available reports whether the git binary is installed
+func Available() bool {
+	_, err := exec.LookPath("git")
+	return err == nil
+}
+
+// IsRepo reports whether dir is inside a git work tree
+func IsRepo(dir string) bool {
+	if !Available() {
+		return false
+	}
+	out, err := run(dir, "rev-parse", "--is-inside-work-tree")
+	return err == nil && out == "true"
+}
+
+// CommitFiles stages the given files and commits them with message
+// Returns false if there was nothing to commit
+func CommitFiles(dir string, paths []string, message string) (bool, error) {
+	args := append([]string{"add", "--"}, paths...)
+	if _, err := run(dir, args...); err != nil {
+		return false, err
+	}
+
+	// Nothing staged for these paths, skip the commit
+	diffArgs := append([]string{"diff", "--cached", "--quiet", "--"}, paths...)
+	if _, err := run(dir, diffArgs...); err == nil {
+		return false, nil
+	}
+
+	commitArgs := append([]string{"commit", "--quiet", "-m", message, "--"}, paths...)
+	if _, err := run(dir, commitArgs...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CurrentBranch returns the checked-out branch name
+func CurrentBranch(dir string) (string, error) {
+	return run(dir, "rev-parse", "--abbrev-ref", "HEAD")
+}
+
+// HasRemote reports whether the named remote is configured
+func HasRemote(dir, remote string) bool {
+	_, err := run(dir, "remote", "get-url", remote)
+	return err == nil
+}
+
+// Sync pulls with rebase from remote/branch and pushes local commits back
+// If the rebase hits conflicts it is aborted, leaving the repository as it was,
+// and a *ConflictError listing the conflicting files is returned
+func Sync(dir, remote, branch string) error {
+	if !HasRemote(dir, remote) {
+		return fmt.Errorf("git remote '%s' is not configured", remote)
+	}
+
+	// A branch that doesn't exist on the remote yet only needs a push
+	if _, err := run(dir, "ls-remote", "--exit-code", "--heads", remote, branch); err != nil {
+		_, pushErr := run(dir, "push", "--quiet", remote, "HEAD:"+branch)
+		return pushErr
+	}
+
+	if _, err := run(dir, "pull", "--rebase", "--autostash", "--quiet", remote, branch); err != nil {
+		conflicts, _ := run(dir, "diff", "--name-only", "--diff-filter=U")
+		if conflicts != "" {
+			// Leave the repository in its pre-sync state
+			_, _ = run(dir, "rebase", "--abort")
+			return &ConflictError{Files: strings.Split(conflicts, "\n")}
+		}
+		return err
+	}
+
+	if _, err := run(dir, "push", "--quiet", remote, "HEAD:"+branch); err != nil {
+		return err
+	}
+	return nil
+}
+
+// IsConflict reports whether err is a sync conflict
+func IsConflict(err error) bool {
+	var conflict *ConflictError
+	return errors.As(err, &conflict)
+}
+
+// run executes a git command in dir and returns its trimmed stdout
+func run(dir string, args ...string) (string, error) {
+	command := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stdout, stderr bytes.Buffer
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+
+	if err := command.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return strings.TrimSpace(stdout.String()), fmt.Errorf("git %s: %s", args[0], message)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
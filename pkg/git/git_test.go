@@ -0,0 +1,141 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// setupIdentity gives test commits an author without touching global git config
+func setupIdentity(t *testing.T) {
+	t.Helper()
+	if !Available() {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "Plan Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "plan@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Plan Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "plan@example.com")
+}
+
+func mustGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	command := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	if out, err := command.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+// setupClones creates a bare remote with an initial commit and two clones of it
+func setupClones(t *testing.T) (string, string) {
+	t.Helper()
+	root := t.TempDir()
+	bare := filepath.Join(root, "remote.git")
+	first := filepath.Join(root, "first")
+	second := filepath.Join(root, "second")
+
+	mustGit(t, root, "init", "--quiet", "--bare", "--initial-branch=main", bare)
+	mustGit(t, root, "clone", "--quiet", bare, first)
+	mustGit(t, first, "checkout", "--quiet", "-b", "main")
+	writeFile(t, filepath.Join(first, "2026-02.plan"), "# 2026-02\n")
+	if _, err := CommitFiles(first, []string{"2026-02.plan"}, "initial"); err != nil {
+		t.Fatalf("CommitFiles() error = %v", err)
+	}
+	mustGit(t, first, "push", "--quiet", "origin", "main")
+	mustGit(t, root, "clone", "--quiet", bare, second)
+
+	return first, second
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestCommitFiles(t *testing.T) {
+	setupIdentity(t)
+	dir := t.TempDir()
+	mustGit(t, dir, "init", "--quiet")
+
+	if !IsRepo(dir) {
+		t.Fatal("IsRepo() = false, want true")
+	}
+
+	file := filepath.Join(dir, "2026-02.plan")
+	writeFile(t, file, "# 2026-02\n")
+
+	committed, err := CommitFiles(dir, []string{file}, "plan: 2026-02-13 (+1 lines)")
+	if err != nil || !committed {
+		t.Fatalf("CommitFiles() = %v, %v, want true", committed, err)
+	}
+
+	// Second commit without changes is a no-op
+	committed, err = CommitFiles(dir, []string{file}, "plan: nothing")
+	if err != nil || committed {
+		t.Errorf("CommitFiles() without changes = %v, %v, want false", committed, err)
+	}
+
+	if IsRepo(t.TempDir()) {
+		t.Error("IsRepo() = true for a plain directory")
+	}
+}
+
+func TestSync(t *testing.T) {
+	setupIdentity(t)
+	first, second := setupClones(t)
+
+	// Change in the second clone is pushed, then pulled into the first
+	writeFile(t, filepath.Join(second, "2026-03.plan"), "# 2026-03\n")
+	if _, err := CommitFiles(second, []string{"2026-03.plan"}, "plan: 2026-03"); err != nil {
+		t.Fatalf("CommitFiles() error = %v", err)
+	}
+	if err := Sync(second, "origin", "main"); err != nil {
+		t.Fatalf("Sync() push error = %v", err)
+	}
+	if err := Sync(first, "origin", "main"); err != nil {
+		t.Fatalf("Sync() pull error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(first, "2026-03.plan")); err != nil {
+		t.Errorf("Sync() did not pull 2026-03.plan: %v", err)
+	}
+
+	if err := Sync(first, "upstream", "main"); err == nil {
+		t.Error("Sync() with unknown remote error = nil, want error")
+	}
+}
+
+func TestSyncConflict(t *testing.T) {
+	setupIdentity(t)
+	first, second := setupClones(t)
+
+	writeFile(t, filepath.Join(first, "2026-02.plan"), "# 2026-02\n\n## 2026-02-13\n* from first\n")
+	if _, err := CommitFiles(first, []string{"2026-02.plan"}, "first"); err != nil {
+		t.Fatalf("CommitFiles() error = %v", err)
+	}
+	if err := Sync(first, "origin", "main"); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	writeFile(t, filepath.Join(second, "2026-02.plan"), "# 2026-02\n\n## 2026-02-13\n* from second\n")
+	if _, err := CommitFiles(second, []string{"2026-02.plan"}, "second"); err != nil {
+		t.Fatalf("CommitFiles() error = %v", err)
+	}
+
+	err := Sync(second, "origin", "main")
+	if !IsConflict(err) {
+		t.Fatalf("Sync() error = %v, want conflict", err)
+	}
+	conflict := err.(*ConflictError)
+	if len(conflict.Files) != 1 || conflict.Files[0] != "2026-02.plan" {
+		t.Errorf("ConflictError.Files = %v, want [2026-02.plan]", conflict.Files)
+	}
+
+	// Rebase was aborted, local commit is intact
+	content, _ := os.ReadFile(filepath.Join(second, "2026-02.plan"))
+	if string(content) != "# 2026-02\n\n## 2026-02-13\n* from second\n" {
+		t.Errorf("local file after aborted sync = %q", content)
+	}
+}
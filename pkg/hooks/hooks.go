@@ -0,0 +1,119 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+// Event names a point in the plan lifecycle where user hooks run
+type Event string
+
+// Lifecycle events
+const (
+	OnMonthCreate Event = "on-month-create"
+	OnDayCreate   Event = "on-day-create"
+	PreEdit       Event = "pre-edit"
+	PostEdit      Event = "post-edit"
+	PostFormat    Event = "post-format"
+	PostAdd       Event = "post-add"
+)
+
+// Events lists all lifecycle events in the order they typically fire
+var Events = []Event{OnMonthCreate, OnDayCreate, PreEdit, PostEdit, PostFormat, PostAdd}
+
+// DefaultTimeout bounds how long a single hook may run
+const DefaultTimeout = 30 * time.Second
+
+// Context describes what triggered a hook; passed to the hook as environment variables
+type Context struct {
+	File string // PLAN_HOOK_FILE: the plan file involved
+	Date string // PLAN_HOOK_DATE: YYYY-MM-DD (or YYYY-MM for month events)
+}
+
+// Config controls how hooks are found and run
+type Config struct {
+	Dir       string           // Directory with executables named after events
+	Commands  map[Event]string // Shell commands from config (take priority over Dir)
+	Timeout   time.Duration    // Per-hook timeout (DefaultTimeout if zero)
+	Disabled  bool             // Skip all hooks (--no-hooks)
+	Operation string           // PLAN_HOOK_OPERATION: the command being run (edit, format, ...)
+	PlansDir  string           // PLAN_HOOK_PLANS_DIR
+}
+
+// Run executes the hook for event, if one is defined
+// Returns an error if the hook exits non-zero or times out; pre-hooks use this to abort
+func (c Config) Run(event Event, ctx Context) error {
+	if c.Disabled {
+		return nil
+	}
+
+	command := c.hookCommand(event)
+	if command == nil {
+		return nil
+	}
+
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	runCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(runCtx, command[0], command[1:]...)
+	setKillGroup(cmd)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"PLAN_HOOK_EVENT="+string(event),
+		"PLAN_HOOK_OPERATION="+c.Operation,
+		"PLAN_HOOK_FILE="+ctx.File,
+		"PLAN_HOOK_DATE="+ctx.Date,
+		"PLAN_HOOK_PLANS_DIR="+c.PlansDir,
+	)
+
+	err := cmd.Run()
+	if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%s hook timed out after %s", event, timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("%s hook failed: %w", event, err)
+	}
+	return nil
+}
+
+// Trigger runs a post-event hook; failures are reported as warnings since the
+// operation itself has already happened
+func (c Config) Trigger(event Event, ctx Context) {
+	if err := c.Run(event, ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// hookCommand returns the argv for an event's hook, or nil if none is defined
+func (c Config) hookCommand(event Event) []string {
+	// Config commands take priority and run through the shell
+	if command := c.Commands[event]; command != "" {
+		if runtime.GOOS == "windows" {
+			return []string{"cmd", "/C", command}
+		}
+		return []string{"sh", "-c", command}
+	}
+
+	if c.Dir == "" {
+		return nil
+	}
+
+	// Executable named after the event in the hooks directory
+	path := filepath.Join(c.Dir, string(event))
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+		return nil
+	}
+	return []string{path}
+}
@@ -0,0 +1,75 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeHook(t *testing.T, dir string, event Event, script string) {
+	t.Helper()
+	path := filepath.Join(dir, string(event))
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+		t.Fatalf("Failed to write hook: %v", err)
+	}
+}
+
+func TestRunFromDirectory(t *testing.T) {
+	hooksDir := t.TempDir()
+	outFile := filepath.Join(t.TempDir(), "out")
+	writeHook(t, hooksDir, OnDayCreate, `echo "$PLAN_HOOK_EVENT $PLAN_HOOK_OPERATION $PLAN_HOOK_DATE $PLAN_HOOK_FILE" > `+outFile)
+
+	cfg := Config{Dir: hooksDir, Operation: "edit"}
+
+	if err := cfg.Run(OnDayCreate, Context{File: "/plans/2026-02.plan", Date: "2026-02-13"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	got, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("hook did not run: %v", err)
+	}
+	want := "on-day-create edit 2026-02-13 /plans/2026-02.plan"
+	if strings.TrimSpace(string(got)) != want {
+		t.Errorf("hook env = %q, want %q", strings.TrimSpace(string(got)), want)
+	}
+
+	// Events without a hook are a no-op
+	if err := cfg.Run(PostFormat, Context{}); err != nil {
+		t.Errorf("Run() without hook error = %v", err)
+	}
+}
+
+func TestRunConfigCommandWinsAndFails(t *testing.T) {
+	hooksDir := t.TempDir()
+	writeHook(t, hooksDir, PreEdit, "exit 0")
+
+	cfg := Config{Dir: hooksDir, Commands: map[Event]string{PreEdit: "exit 3"}}
+
+	if err := cfg.Run(PreEdit, Context{}); err == nil {
+		t.Error("Run() error = nil, want failure from config command")
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	cfg := Config{Commands: map[Event]string{PreEdit: "sleep 5"}, Timeout: 100 * time.Millisecond}
+
+	start := time.Now()
+	err := cfg.Run(PreEdit, Context{})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Run() error = %v, want timeout", err)
+	}
+	if time.Since(start) > 3*time.Second {
+		t.Error("Run() did not stop at the timeout")
+	}
+}
+
+func TestRunDisabled(t *testing.T) {
+	cfg := Config{Commands: map[Event]string{PreEdit: "exit 1"}, Disabled: true}
+
+	if err := cfg.Run(PreEdit, Context{}); err != nil {
+		t.Errorf("Run() with hooks disabled error = %v", err)
+	}
+}
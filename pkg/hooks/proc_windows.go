@@ -0,0 +1,8 @@
+//go:build windows
+
+package hooks
+
+import "os/exec"
+
+// setKillGroup is a no-op on Windows; the default cancel kills the hook process
+func setKillGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,421 @@
+// Package journal reads and writes a plans directory for programs embedding the journal
+//
+// A Journal is opened on a plans directory with explicit Options; it doesn't read the
+// plan config file or environment. The plan command is built on this package, so the
+// CLI and programs using it see the same files and behavior:
+//
+//	j, err := journal.Open("/home/me/plans", journal.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	day, err := j.Day(time.Now())
+//	if errors.Is(err, journal.ErrNotFound) {
+//		// Nothing written today
+//	}
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+	"github.com/abyss/plan-journal-cli/pkg/hooks"
+	"github.com/abyss/plan-journal-cli/pkg/planfile"
+)
+
+var (
+	// ErrNotFound matches every *NotFoundError with errors.Is
+	ErrNotFound = errors.New("not found")
+	// ErrLocked matches every *LockedError with errors.Is
+	ErrLocked = errors.New("journal is locked")
+	// ErrInvalidEntry is returned when appended text would start a new section
+	ErrInvalidEntry = errors.New("entry lines must not start with a # header")
+)
+
+// NotFoundError is returned when a day, month, or file has no entries
+type NotFoundError struct {
+	Target string // YYYY-MM-DD, YYYY-MM, or the file that was asked for
+}
+
+func (e *NotFoundError) Error() string {
+	switch {
+	case dateutil.IsValidDate(e.Target):
+		return fmt.Sprintf("no entries found for %s", e.Target)
+	case dateutil.IsValidMonth(e.Target):
+		return fmt.Sprintf("no plan file found for %s", e.Target)
+	default:
+		return fmt.Sprintf("file not found: %s", e.Target)
+	}
+}
+
+// Is makes errors.Is(err, ErrNotFound) true
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// LockedError is returned when an encrypted journal can't be unlocked
+// Err is planfile.ErrNoPassphrase, vault.ErrWrongPassphrase, or the passphrase source's error
+type LockedError struct {
+	Err error
+}
+
+func (e *LockedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LockedError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes errors.Is(err, ErrLocked) true
+func (e *LockedError) Is(target error) bool {
+	return target == ErrLocked
+}
+
+// Options configure a Journal
+type Options struct {
+	// Preamble is written below the month header of new month files and by Format
+	Preamble string
+	// Passphrase unlocks an encrypted journal. If empty, AskPassphrase is called the
+	// first time an encrypted file is read
+	Passphrase string
+	// AskPassphrase supplies the passphrase when Passphrase is empty (e.g. a prompt)
+	AskPassphrase func() (string, error)
+	// Hooks runs lifecycle hooks after appends and formats; the zero Config runs none
+	Hooks hooks.Config
+	// FS holds the plans directory instead of the OS, with the month files at its root
+	// (e.g. planfile.NewMemFS(), os.DirFS, or a zip.Reader). Writes need a
+	// planfile.WritableFS; other filesystems are read-only
+	FS fs.FS
+}
+
+// Journal is a plans directory of YYYY-MM.plan month files
+type Journal struct {
+	dir   string
+	opts  Options
+	store *planfile.Store
+}
+
+// Day is one date section of a month file
+type Day struct {
+	Date   string   `json:"date"`            // YYYY-MM-DD
+	Header string   `json:"header"`          // The full header line (e.g. "## 2026-02-13 - Planning")
+	Title  string   `json:"title,omitempty"` // The text after the date in the header, if any
+	Lines  []string `json:"lines"`           // Content lines as written, without trailing empty lines
+}
+
+// Text returns the header and non-empty lines of the day, as plan read prints them
+func (d *Day) Text() string {
+	return planfile.SectionText(d.Header, d.Lines)
+}
+
+// Month is a parsed month file
+type Month struct {
+	Month    string `json:"month"`  // YYYY-MM
+	Header   string `json:"header"` // The month header line (e.g. "# 2026-02")
+	Preamble string `json:"preamble"`
+	Days     []Day  `json:"days"` // In file order
+	Text     string `json:"text"` // The file content as written
+}
+
+// Match is a day with lines containing a search query
+type Match struct {
+	Date  string   `json:"date"`            // YYYY-MM-DD
+	Title string   `json:"title,omitempty"` // The day's title, if any
+	Lines []string `json:"lines"`           // The matching lines of Day.Text, including the header if it matches
+}
+
+// FormatResult describes what Format changed
+type FormatResult struct {
+	File    string   `json:"file"`    // The plaintext path of the formatted file
+	Changes []string `json:"changes"` // Empty if the file was already formatted
+}
+
+// Summary counts the lines, words, tasks and tags of a day
+type Summary = planfile.DaySummary
+
+// Open opens the journal in dir
+// The directory doesn't have to exist yet; it is created by the first Append
+// With Options.FS, dir only names the journal: its files are read from and written to the FS
+func Open(dir string, opts Options) (*Journal, error) {
+	if dir == "" {
+		return nil, errors.New("journal directory must not be empty")
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if opts.FS == nil {
+		if info, err := os.Stat(abs); err == nil && !info.IsDir() {
+			return nil, fmt.Errorf("journal path is not a directory: %s", abs)
+		}
+	}
+
+	store := &planfile.Store{Dir: abs, FS: opts.FS, Passphrase: opts.AskPassphrase}
+	if opts.Passphrase != "" && store.Encrypted() {
+		if err := store.Unlock(opts.Passphrase); err != nil {
+			return nil, wrapErr(err)
+		}
+	}
+	return &Journal{dir: abs, opts: opts, store: store}, nil
+}
+
+// Dir returns the absolute path of the plans directory
+func (j *Journal) Dir() string {
+	return j.dir
+}
+
+// Store returns the plan file store of the journal, for lower-level planfile operations
+func (j *Journal) Store() *planfile.Store {
+	return j.store
+}
+
+// Encrypted reports whether the journal is encrypted at rest
+func (j *Journal) Encrypted() bool {
+	return j.store.Encrypted()
+}
+
+// Day returns the date section for date
+func (j *Journal) Day(date time.Time) (*Day, error) {
+	dateStr := dateutil.FormatDate(date)
+	pf, err := j.parseMonth(date)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, &NotFoundError{Target: dateStr}
+		}
+		return nil, err
+	}
+	if _, ok := pf.Dates[dateStr]; !ok {
+		return nil, &NotFoundError{Target: dateStr}
+	}
+	return newDay(pf, dateStr), nil
+}
+
+// Month returns the month file for the month of date
+func (j *Journal) Month(date time.Time) (*Month, error) {
+	pf, err := j.parseMonth(date)
+	if err != nil {
+		return nil, err
+	}
+	content, err := j.store.ReadFile(j.monthPath(date))
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	month := &Month{
+		Month:    dateutil.FormatMonth(date),
+		Header:   pf.MonthHeader,
+		Preamble: pf.Preamble,
+		Text:     string(content),
+	}
+	for _, dateStr := range pf.DateOrder {
+		month.Days = append(month.Days, *newDay(pf, dateStr))
+	}
+	return month, nil
+}
+
+// Range returns the days from from to to (inclusive), oldest first
+func (j *Journal) Range(from, to time.Time) ([]Day, error) {
+	first, last := dateutil.FormatDate(from), dateutil.FormatDate(to)
+	if first > last {
+		return nil, fmt.Errorf("range starts after it ends: %s to %s", first, last)
+	}
+
+	var days []Day
+	month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.Local)
+	for ; dateutil.FormatMonth(month) <= last[:7]; month = month.AddDate(0, 1, 0) {
+		pf, err := j.parseMonth(month)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, dateStr := range pf.DateOrder {
+			if dateStr >= first && dateStr <= last {
+				days = append(days, *newDay(pf, dateStr))
+			}
+		}
+	}
+
+	sort.SliceStable(days, func(i, k int) bool {
+		return days[i].Date < days[k].Date
+	})
+	return days, nil
+}
+
+// Append adds lines to the end of the date section for date
+// The month file and date header are created if needed. Each string may hold several
+// lines, but none may start with "# " or "## ", which would begin a new section
+func (j *Journal) Append(date time.Time, lines ...string) error {
+	var entry []string
+	for _, text := range lines {
+		for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+			line = strings.TrimSuffix(line, "\r")
+			if strings.HasPrefix(line, "# ") || strings.HasPrefix(line, "## ") {
+				return fmt.Errorf("%w: %q", ErrInvalidEntry, line)
+			}
+			entry = append(entry, line)
+		}
+	}
+	if len(entry) == 0 {
+		return nil
+	}
+
+	created, err := j.store.AppendLines(date, j.opts.Preamble, entry)
+	if err != nil {
+		return wrapErr(err)
+	}
+
+	file := j.store.StoredPath(j.monthPath(date))
+	if created.Month {
+		j.opts.Hooks.Trigger(hooks.OnMonthCreate, hooks.Context{File: file, Date: dateutil.FormatMonth(date)})
+	}
+	if created.Day {
+		j.opts.Hooks.Trigger(hooks.OnDayCreate, hooks.Context{File: file, Date: dateutil.FormatDate(date)})
+	}
+	j.opts.Hooks.Trigger(hooks.PostAdd, hooks.Context{File: file, Date: dateutil.FormatDate(date)})
+	return nil
+}
+
+// Search returns the days containing query (case-insensitive), oldest first
+func (j *Journal) Search(query string) ([]Match, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, errors.New("search query must not be empty")
+	}
+	months, err := j.Months()
+	if err != nil {
+		return nil, err
+	}
+
+	needle := strings.ToLower(query)
+	var matches []Match
+	for _, monthStr := range months {
+		month, _ := time.Parse("2006-01", monthStr)
+		pf, err := j.parseMonth(month)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, dateStr := range pf.DateOrder {
+			day := newDay(pf, dateStr)
+			var lines []string
+			for _, line := range strings.Split(day.Text(), "\n") {
+				if strings.Contains(strings.ToLower(line), needle) {
+					lines = append(lines, line)
+				}
+			}
+			if len(lines) > 0 {
+				matches = append(matches, Match{Date: day.Date, Title: day.Title, Lines: lines})
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, k int) bool {
+		return matches[i].Date < matches[k].Date
+	})
+	return matches, nil
+}
+
+// Format reorders date sections, updates the preamble to Options.Preamble, and
+// normalizes spacing. target is a date (YYYY-MM, YYYY-MM-DD, today, yesterday,
+// tomorrow) or a plan file path, absolute or relative to the plans directory
+func (j *Journal) Format(target string) (*FormatResult, error) {
+	filePath, err := j.store.ResolveTargetFile(target)
+	if err != nil {
+		if date, dateErr := dateutil.ParseTarget(target); dateErr == nil {
+			return nil, &NotFoundError{Target: dateutil.FormatMonth(date)}
+		}
+		return nil, &NotFoundError{Target: target}
+	}
+
+	changes, err := j.store.FormatFile(filePath, j.opts.Preamble)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	if changes == nil {
+		changes = []string{}
+	} else {
+		// Only a rewritten file is reported to post-format hooks
+		month := strings.TrimSuffix(filepath.Base(filePath), ".plan")
+		if !dateutil.IsValidMonth(month) {
+			month = ""
+		}
+		j.opts.Hooks.Trigger(hooks.PostFormat, hooks.Context{File: j.store.StoredPath(filePath), Date: month})
+	}
+	return &FormatResult{File: filePath, Changes: changes}, nil
+}
+
+// Months returns the months (YYYY-MM) that have a month file, oldest first
+func (j *Journal) Months() ([]string, error) {
+	return j.store.DiscoverMonths()
+}
+
+// Dates returns the dates of each month (YYYY-MM), sorted within the month
+// filter can be empty (all dates), YYYY (a year), or YYYY-MM (a month)
+func (j *Journal) Dates(filter string) (map[string][]string, error) {
+	dates, err := j.store.DiscoverDates(filter)
+	return dates, wrapErr(err)
+}
+
+// Summaries returns a summary of every day, oldest first
+// filter can be empty (all dates), YYYY (a year), or YYYY-MM (a month)
+// Unchanged month files are read from the index (see planfile.IndexFileName)
+func (j *Journal) Summaries(filter string) ([]Summary, error) {
+	days, err := j.store.DiscoverDays(filter)
+	return days, wrapErr(err)
+}
+
+// Reindex rebuilds the index from every month file
+// Returns the number of files and days indexed
+func (j *Journal) Reindex() (int, int, error) {
+	files, days, err := j.store.Reindex()
+	return files, days, wrapErr(err)
+}
+
+// monthPath returns the plaintext path of the month file for date
+func (j *Journal) monthPath(date time.Time) string {
+	return filepath.Join(j.dir, dateutil.MonthFileName(date))
+}
+
+// parseMonth parses the month file for date; a missing file is a *NotFoundError
+func (j *Journal) parseMonth(date time.Time) (*planfile.PlanFile, error) {
+	pf, err := j.store.ParseFile(j.monthPath(date))
+	if os.IsNotExist(err) {
+		return nil, &NotFoundError{Target: dateutil.FormatMonth(date)}
+	}
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	return pf, nil
+}
+
+// newDay builds the Day for a date of a parsed month file
+func newDay(pf *planfile.PlanFile, date string) *Day {
+	header, ok := pf.DateHeaders[date]
+	if !ok {
+		header = "## " + date
+	}
+	lines := append([]string{}, pf.Dates[date]...)
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return &Day{Date: date, Header: header, Title: planfile.HeaderTitle(date, header), Lines: lines}
+}
+
+// wrapErr turns failures to unlock an encrypted journal into a *LockedError
+func wrapErr(err error) error {
+	if err != nil && planfile.IsUnlockError(err) {
+		return &LockedError{Err: err}
+	}
+	return err
+}
@@ -0,0 +1,385 @@
+package journal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/hooks"
+	"github.com/abyss/plan-journal-cli/pkg/planfile"
+	"github.com/abyss/plan-journal-cli/pkg/vault"
+)
+
+const testFeb = `# 2026-02
+
+Preamble
+
+## 2026-02-14
+* Valentine's #errands
+
+
+## 2026-02-13 - Planning
+* [ ] Write the report
+* Lunch with Sam
+
+`
+
+const testMar = `# 2026-03
+
+## 2026-03-01
+* Report sent
+`
+
+// testJournal opens a journal with February (out of order) and March
+func testJournal(t *testing.T, opts Options) *Journal {
+	t.Helper()
+	tmpDir := t.TempDir()
+	for name, content := range map[string]string{"2026-02.plan": testFeb, "2026-03.plan": testMar} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+	j, err := Open(tmpDir, opts)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	return j
+}
+
+func date(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return t
+}
+
+func TestOpen(t *testing.T) {
+	if _, err := Open("", Options{}); err == nil {
+		t.Error("Open(\"\") should fail")
+	}
+
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(file, Options{}); err == nil {
+		t.Error("Open() on a file should fail")
+	}
+
+	// A missing directory is an empty journal
+	j, err := Open(filepath.Join(t.TempDir(), "plans"), Options{})
+	if err != nil {
+		t.Fatalf("Open() missing dir error = %v", err)
+	}
+	if months, err := j.Months(); err != nil || len(months) != 0 {
+		t.Errorf("Months() = %v, %v, want none", months, err)
+	}
+}
+
+func TestDay(t *testing.T) {
+	j := testJournal(t, Options{})
+
+	day, err := j.Day(date("2026-02-13"))
+	if err != nil {
+		t.Fatalf("Day() error = %v", err)
+	}
+	want := &Day{
+		Date:   "2026-02-13",
+		Header: "## 2026-02-13 - Planning",
+		Title:  "Planning",
+		Lines:  []string{"* [ ] Write the report", "* Lunch with Sam"},
+	}
+	if !reflect.DeepEqual(day, want) {
+		t.Errorf("Day() = %+v, want %+v", day, want)
+	}
+	if day.Text() != "## 2026-02-13 - Planning\n* [ ] Write the report\n* Lunch with Sam" {
+		t.Errorf("Text() = %q", day.Text())
+	}
+
+	for _, missing := range []string{"2026-02-15", "2026-05-01"} {
+		_, err := j.Day(date(missing))
+		var notFound *NotFoundError
+		if !errors.Is(err, ErrNotFound) || !errors.As(err, &notFound) || notFound.Target != missing {
+			t.Errorf("Day(%s) error = %v, want NotFoundError for the date", missing, err)
+		}
+	}
+}
+
+func TestMonth(t *testing.T) {
+	j := testJournal(t, Options{})
+
+	month, err := j.Month(date("2026-02-01"))
+	if err != nil {
+		t.Fatalf("Month() error = %v", err)
+	}
+	if month.Month != "2026-02" || month.Header != "# 2026-02" || month.Preamble != "Preamble" || month.Text != testFeb {
+		t.Errorf("Month() = %+v", month)
+	}
+	if len(month.Days) != 2 || month.Days[0].Date != "2026-02-14" {
+		t.Errorf("Month().Days = %+v, want file order", month.Days)
+	}
+
+	if _, err := j.Month(date("2026-05-01")); err == nil || err.Error() != "no plan file found for 2026-05" {
+		t.Errorf("Month() missing error = %v", err)
+	}
+}
+
+func TestRange(t *testing.T) {
+	j := testJournal(t, Options{})
+
+	days, err := j.Range(date("2026-01-20"), date("2026-03-01"))
+	if err != nil {
+		t.Fatalf("Range() error = %v", err)
+	}
+	var dates []string
+	for _, day := range days {
+		dates = append(dates, day.Date)
+	}
+	if !reflect.DeepEqual(dates, []string{"2026-02-13", "2026-02-14", "2026-03-01"}) {
+		t.Errorf("Range() dates = %v", dates)
+	}
+
+	days, err = j.Range(date("2026-02-14"), date("2026-02-28"))
+	if err != nil || len(days) != 1 || days[0].Date != "2026-02-14" {
+		t.Errorf("Range() within a month = %+v, %v", days, err)
+	}
+
+	if _, err := j.Range(date("2026-03-01"), date("2026-02-01")); err == nil {
+		t.Error("Range() with from after to should fail")
+	}
+}
+
+func TestAppend(t *testing.T) {
+	j := testJournal(t, Options{Preamble: "New month"})
+
+	if err := j.Append(date("2026-02-13"), "* Call the bank", "* Two\n  lines\n"); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	day, err := j.Day(date("2026-02-13"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"* [ ] Write the report", "* Lunch with Sam", "* Call the bank", "* Two", "  lines"}
+	if !reflect.DeepEqual(day.Lines, want) {
+		t.Errorf("Lines after Append() = %q, want %q", day.Lines, want)
+	}
+
+	// A new month gets a file with the preamble and a header
+	if err := j.Append(date("2026-04-02"), "* First"); err != nil {
+		t.Fatalf("Append() new month error = %v", err)
+	}
+	month, err := j.Month(date("2026-04-02"))
+	if err != nil || month.Preamble != "New month" || len(month.Days) != 1 || month.Days[0].Lines[0] != "* First" {
+		t.Errorf("Month() after Append() = %+v, %v", month, err)
+	}
+
+	if err := j.Append(date("2026-02-13"), "* ok", "## 2026-02-20"); !errors.Is(err, ErrInvalidEntry) {
+		t.Errorf("Append() with a header error = %v, want ErrInvalidEntry", err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	j := testJournal(t, Options{})
+
+	matches, err := j.Search("REPORT")
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	want := []Match{
+		{Date: "2026-02-13", Title: "Planning", Lines: []string{"* [ ] Write the report"}},
+		{Date: "2026-03-01", Lines: []string{"* Report sent"}},
+	}
+	if !reflect.DeepEqual(matches, want) {
+		t.Errorf("Search() = %+v, want %+v", matches, want)
+	}
+
+	if matches, err := j.Search("planning"); err != nil || len(matches) != 1 || matches[0].Lines[0] != "## 2026-02-13 - Planning" {
+		t.Errorf("Search() on a title = %+v, %v", matches, err)
+	}
+	if _, err := j.Search(" "); err == nil {
+		t.Error("Search() with an empty query should fail")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	j := testJournal(t, Options{Preamble: "Preamble"})
+
+	result, err := j.Format("2026-02")
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	if result.File != filepath.Join(j.Dir(), "2026-02.plan") || !reflect.DeepEqual(result.Changes, []string{"Reordered date sections chronologically"}) {
+		t.Errorf("Format() = %+v", result)
+	}
+
+	result, err = j.Format("2026-02.plan")
+	if err != nil || len(result.Changes) != 0 {
+		t.Errorf("Format() again = %+v, %v, want no changes", result, err)
+	}
+
+	if _, err := j.Format("2026-05"); !errors.Is(err, ErrNotFound) || err.Error() != "no plan file found for 2026-05" {
+		t.Errorf("Format() missing month error = %v", err)
+	}
+	if _, err := j.Format("nope.plan"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Format() missing file error = %v", err)
+	}
+}
+
+func TestHooks(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "hooks.log")
+	record := `echo "$PLAN_HOOK_EVENT $PLAN_HOOK_DATE" >> ` + logFile
+	j := testJournal(t, Options{Hooks: hooks.Config{Commands: map[hooks.Event]string{
+		hooks.OnMonthCreate: record,
+		hooks.OnDayCreate:   record,
+		hooks.PostAdd:       record,
+		hooks.PostFormat:    record,
+	}}})
+
+	// Hooks belong to the journal they were given to
+	if err := testJournal(t, Options{}).Append(date("2026-04-01"), "* Quiet"); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	if err := j.Append(date("2026-04-02"), "* First"); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if err := j.Append(date("2026-04-02"), "* Second"); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	// Only the first format rewrites the file
+	for range 2 {
+		if _, err := j.Format("2026-02"); err != nil {
+			t.Fatalf("Format() error = %v", err)
+		}
+	}
+
+	got, _ := os.ReadFile(logFile)
+	want := "on-month-create 2026-04\non-day-create 2026-04-02\npost-add 2026-04-02\npost-add 2026-04-02\npost-format 2026-02\n"
+	if string(got) != want {
+		t.Errorf("hooks ran:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMonthsAndSummaries(t *testing.T) {
+	j := testJournal(t, Options{})
+
+	months, err := j.Months()
+	if err != nil || !reflect.DeepEqual(months, []string{"2026-02", "2026-03"}) {
+		t.Errorf("Months() = %v, %v", months, err)
+	}
+
+	summaries, err := j.Summaries("2026-02")
+	if err != nil || len(summaries) != 2 || summaries[0].Date != "2026-02-13" || summaries[1].Tags[0] != "errands" {
+		t.Errorf("Summaries() = %+v, %v", summaries, err)
+	}
+
+	dates, err := j.Dates("2026")
+	if err != nil || !reflect.DeepEqual(dates["2026-02"], []string{"2026-02-13", "2026-02-14"}) {
+		t.Errorf("Dates() = %v, %v", dates, err)
+	}
+}
+
+func TestEncryptedJournal(t *testing.T) {
+	orig := vault.DefaultParams
+	vault.DefaultParams = vault.Params{LogN: 10, R: 8, P: 1}
+	t.Cleanup(func() { vault.DefaultParams = orig })
+
+	dir := testJournal(t, Options{}).Dir()
+	if _, err := (&planfile.Store{Dir: dir}).EncryptDirectory("secret"); err != nil {
+		t.Fatalf("EncryptDirectory() error = %v", err)
+	}
+
+	if _, err := Open(dir, Options{Passphrase: "wrong"}); !errors.Is(err, ErrLocked) || !errors.Is(err, vault.ErrWrongPassphrase) {
+		t.Errorf("Open() with a wrong passphrase error = %v, want ErrLocked", err)
+	}
+
+	// Without a passphrase or source, reads report a locked journal
+	locked, err := Open(dir, Options{})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if _, err := locked.Day(date("2026-02-13")); !errors.Is(err, ErrLocked) {
+		t.Errorf("Day() on a locked journal error = %v, want ErrLocked", err)
+	}
+
+	// AskPassphrase is only called once an encrypted file is read
+	asked := 0
+	prompted, err := Open(dir, Options{AskPassphrase: func() (string, error) {
+		asked++
+		return "secret", nil
+	}})
+	if err != nil || asked != 0 {
+		t.Fatalf("Open() error = %v, asked %d times", err, asked)
+	}
+	if _, err := prompted.Day(date("2026-02-13")); err != nil || asked != 1 {
+		t.Errorf("Day() error = %v, asked %d times, want once", err, asked)
+	}
+
+	j, err := Open(dir, Options{Passphrase: "secret"})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if err := j.Append(date("2026-02-13"), "* Secret"); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	matches, err := j.Search("secret")
+	if err != nil || len(matches) != 1 || matches[0].Date != "2026-02-13" {
+		t.Errorf("Search() = %+v, %v", matches, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "2026-02.plan.enc"))
+	if err != nil || strings.Contains(string(data), "Secret") {
+		t.Errorf("month file should stay encrypted, err = %v", err)
+	}
+}
+
+func TestFilesystemJournal(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "plans")
+	mem := planfile.NewMemFS()
+	if err := mem.WriteFile("2026-02.plan", []byte(testFeb), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	j, err := Open(dir, Options{FS: mem})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if err := j.Append(date("2026-03-01"), "* In memory"); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if months, err := j.Months(); err != nil || !reflect.DeepEqual(months, []string{"2026-02", "2026-03"}) {
+		t.Errorf("Months() = %v, %v", months, err)
+	}
+	if matches, err := j.Search("memory"); err != nil || len(matches) != 1 || matches[0].Date != "2026-03-01" {
+		t.Errorf("Search() = %+v, %v", matches, err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("journal wrote to disk, Stat() error = %v", err)
+	}
+
+	// Another journal on the same directory reads its own filesystem
+	osJournal, err := Open(dir, Options{})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if months, err := osJournal.Months(); err != nil || len(months) != 0 {
+		t.Errorf("Months() on the OS = %v, %v, want none", months, err)
+	}
+
+	// A read-only filesystem can be read but not appended to
+	ro, err := Open(dir, Options{FS: os.DirFS(testJournal(t, Options{}).Dir())})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if day, err := ro.Day(date("2026-03-01")); err != nil || day.Lines[0] != "* Report sent" {
+		t.Errorf("Day() = %+v, %v", day, err)
+	}
+	if err := ro.Append(date("2026-03-01"), "* Nope"); !errors.Is(err, planfile.ErrReadOnly) {
+		t.Errorf("Append() error = %v, want ErrReadOnly", err)
+	}
+	if months, err := j.Months(); err != nil || len(months) != 2 {
+		t.Errorf("Months() after opening other journals = %v, %v", months, err)
+	}
+}
@@ -1,6 +1,7 @@
 package output
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/fatih/color"
@@ -18,7 +19,13 @@ func SetColorsDisabled(disabled bool) {
 	}
 }
 
+// ColorsDisabled reports whether color output is disabled
+func ColorsDisabled() bool {
+	return colorsDisabled
+}
+
 // Define reusable color functions for consistent styling
+// Header, the date colors, Tag, TaskOpen, and TaskDone are theme roles (see ApplyTheme)
 var (
 	// Headers and titles
 	Header = color.New(color.Bold).SprintFunc() // Just bold, no color
@@ -31,14 +38,34 @@ var (
 	Info    = func(s string) string { return s } // Default terminal color
 
 	// Content colors - minimal approach
-	DateBlue  = func(s string) string { return s }                // Default color for future dates
+	DateBlue  = fmt.Sprint                                        // Future dates (default color)
 	DateGreen = color.New(color.FgGreen, color.Bold).SprintFunc() // Today - the most important highlight
-	DateGray  = func(s string) string { return s }                // Default color for past dates
+	DateGray  = fmt.Sprint                                        // Past dates (default color)
 	FilePath  = func(s string) string { return s }                // Default color for file paths
 	Number    = func(s string) string { return s }                // Default color for numbers
 	Highlight = color.New(color.FgGreen).SprintFunc()             // Keep green for [today] label
+	Selected  = color.New(color.ReverseVideo).SprintFunc()        // Selected row in interactive views
+
+	// Rendered Markdown in entries
+	Italic = color.New(color.Italic).SprintFunc()
+	Code   = color.New(color.FgCyan).SprintFunc()
+	Link   = color.New(color.Underline).SprintFunc()
+
+	// Tags and task markers in entries
+	Tag      = fmt.Sprint
+	TaskOpen = color.New(color.FgYellow).SprintFunc()
+	TaskDone = color.New(color.FgGreen).SprintFunc()
 )
 
+// HeatLevels shade heatmap cells, from no activity to the most
+var HeatLevels = []func(a ...interface{}) string{
+	color.New(color.FgHiBlack).SprintFunc(),
+	color.New(color.FgGreen, color.Faint).SprintFunc(),
+	color.New(color.FgGreen).SprintFunc(),
+	color.New(color.FgHiGreen).SprintFunc(),
+	color.New(color.FgHiGreen, color.Bold).SprintFunc(),
+}
+
 // FormatDate colors a date string based on whether it's today, past, or future
 func FormatDate(dateStr string, today time.Time) string {
 	todayStr := today.Format("2006-01-02")
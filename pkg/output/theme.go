@@ -0,0 +1,266 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Theme maps color roles to style specs
+// A spec is a space-separated list of attributes (bold, faint, italic, underline, reverse),
+// a foreground color, and optionally a background color prefixed with "on-". Colors are
+// names (red, bright-blue, gray), 256-color numbers (0-255), or truecolor hex (#ff8800).
+type Theme map[string]string
+
+// Color roles that themes and config overrides can set
+const (
+	RoleHeader   = "header"
+	RoleToday    = "today"
+	RolePast     = "past"
+	RoleFuture   = "future"
+	RoleTag      = "tag"
+	RoleTaskOpen = "task-open"
+	RoleTaskDone = "task-done"
+)
+
+// Roles lists the color roles in display order
+var Roles = []string{RoleHeader, RoleToday, RolePast, RoleFuture, RoleTag, RoleTaskOpen, RoleTaskDone}
+
+// DefaultTheme is used when no theme is configured; it matches the original palette
+const DefaultTheme = "minimal"
+
+// Themes are the built-in themes
+var Themes = map[string]Theme{
+	"minimal": {
+		RoleHeader:   "bold",
+		RoleToday:    "bold green",
+		RolePast:     "",
+		RoleFuture:   "",
+		RoleTag:      "",
+		RoleTaskOpen: "yellow",
+		RoleTaskDone: "green",
+	},
+	"dark": {
+		RoleHeader:   "bold bright-white",
+		RoleToday:    "bold bright-green",
+		RolePast:     "gray",
+		RoleFuture:   "bright-blue",
+		RoleTag:      "bright-magenta",
+		RoleTaskOpen: "bright-yellow",
+		RoleTaskDone: "green",
+	},
+	"light": {
+		RoleHeader:   "bold black",
+		RoleToday:    "bold 28",
+		RolePast:     "244",
+		RoleFuture:   "blue",
+		RoleTag:      "magenta",
+		RoleTaskOpen: "130",
+		RoleTaskDone: "28",
+	},
+	"high-contrast": {
+		RoleHeader:   "bold underline",
+		RoleToday:    "bold black on-bright-green",
+		RolePast:     "white",
+		RoleFuture:   "bold bright-cyan",
+		RoleTag:      "bold bright-magenta",
+		RoleTaskOpen: "bold black on-bright-yellow",
+		RoleTaskDone: "bold bright-green",
+	},
+}
+
+// activeTheme is the theme set by ApplyTheme, with overrides merged in
+var activeTheme = Themes[DefaultTheme]
+
+// activeThemeName is the name of the theme set by ApplyTheme
+var activeThemeName = DefaultTheme
+
+// ThemeNames returns the sorted names of the built-in themes
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ActiveTheme returns the name and merged roles of the theme in use
+func ActiveTheme() (string, Theme) {
+	return activeThemeName, activeTheme
+}
+
+// ApplyTheme sets the colors for a built-in theme, with individual roles overridden
+// The theme is left unchanged if the name, a role, or a spec is invalid
+func ApplyTheme(name string, overrides map[string]string) error {
+	base, ok := Themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme: %s (expected one of: %s)", name, strings.Join(ThemeNames(), ", "))
+	}
+
+	theme := make(Theme, len(base))
+	for role, spec := range base {
+		theme[role] = spec
+	}
+	for role, spec := range overrides {
+		if _, known := base[role]; !known {
+			return fmt.Errorf("unknown color role: %s (expected one of: %s)", role, strings.Join(Roles, ", "))
+		}
+		theme[role] = spec
+	}
+
+	styles := make(map[string]func(a ...interface{}) string, len(theme))
+	for role, spec := range theme {
+		style, err := ParseStyle(spec, TrueColorSupported())
+		if err != nil {
+			return fmt.Errorf("invalid color for %s: %w", role, err)
+		}
+		styles[role] = style
+	}
+
+	activeTheme, activeThemeName = theme, name
+	Header = styles[RoleHeader]
+	DateGreen = styles[RoleToday]
+	DateGray = styles[RolePast]
+	DateBlue = styles[RoleFuture]
+	Tag = styles[RoleTag]
+	TaskOpen = styles[RoleTaskOpen]
+	TaskDone = styles[RoleTaskDone]
+	return nil
+}
+
+// TrueColorSupported reports whether the terminal advertises 24-bit color
+func TrueColorSupported() bool {
+	colorTerm := strings.ToLower(os.Getenv("COLORTERM"))
+	return colorTerm == "truecolor" || colorTerm == "24bit"
+}
+
+// styleAttributes are the text attributes a spec may use
+var styleAttributes = map[string]color.Attribute{
+	"bold":      color.Bold,
+	"faint":     color.Faint,
+	"dim":       color.Faint,
+	"italic":    color.Italic,
+	"underline": color.Underline,
+	"reverse":   color.ReverseVideo,
+}
+
+// colorNames are the 16 basic colors as offsets from black
+var colorNames = map[string]int{
+	"black": 0, "red": 1, "green": 2, "yellow": 3, "blue": 4, "magenta": 5, "cyan": 6, "white": 7,
+}
+
+// ParseStyle turns a style spec into a color function
+// Hex colors are approximated with the 256-color palette unless trueColor is set
+func ParseStyle(spec string, trueColor bool) (func(a ...interface{}) string, error) {
+	var attrs []color.Attribute
+	for _, token := range strings.Fields(strings.ToLower(spec)) {
+		if token == "none" || token == "default" {
+			continue
+		}
+		if attr, ok := styleAttributes[token]; ok {
+			attrs = append(attrs, attr)
+			continue
+		}
+
+		background := false
+		if name, found := strings.CutPrefix(token, "on-"); found {
+			token, background = name, true
+		}
+		codes, err := colorCodes(token, background, trueColor)
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, codes...)
+	}
+
+	if len(attrs) == 0 {
+		return fmt.Sprint, nil
+	}
+	return color.New(attrs...).SprintFunc(), nil
+}
+
+// colorCodes returns the SGR parameters selecting a foreground or background color
+func colorCodes(token string, background, trueColor bool) ([]color.Attribute, error) {
+	base, extended := 30, color.Attribute(38)
+	if background {
+		base, extended = 40, 48
+	}
+
+	bright := false
+	if name, found := strings.CutPrefix(token, "bright-"); found {
+		token, bright = name, true
+	}
+	if token == "gray" || token == "grey" {
+		token, bright = "black", true
+	}
+	if offset, ok := colorNames[token]; ok {
+		if bright {
+			base += 60 // 90-97 and 100-107
+		}
+		return []color.Attribute{color.Attribute(base + offset)}, nil
+	}
+	if bright {
+		return nil, fmt.Errorf("unknown color: bright-%s", token)
+	}
+
+	if n, err := strconv.Atoi(token); err == nil && n >= 0 && n <= 255 {
+		return []color.Attribute{extended, 5, color.Attribute(n)}, nil
+	}
+
+	if hex, found := strings.CutPrefix(token, "#"); found && len(hex) == 6 {
+		value, err := strconv.ParseUint(hex, 16, 32)
+		if err == nil {
+			r, g, b := int(value>>16), int(value>>8&0xff), int(value&0xff)
+			if trueColor {
+				return []color.Attribute{extended, 2, color.Attribute(r), color.Attribute(g), color.Attribute(b)}, nil
+			}
+			return []color.Attribute{extended, 5, color.Attribute(RGBTo256(r, g, b))}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown color: %s", token)
+}
+
+// RGBTo256 returns the closest color in the 256-color palette's 6x6x6 cube or gray ramp
+func RGBTo256(r, g, b int) int {
+	// Nearest level in the cube for each channel (levels 0, 95, 135, 175, 215, 255)
+	level := func(v int) int {
+		if v < 48 {
+			return 0
+		}
+		if v < 115 {
+			return 1
+		}
+		return (v - 35) / 40
+	}
+	levelValue := func(l int) int {
+		if l == 0 {
+			return 0
+		}
+		return 55 + l*40
+	}
+	cr, cg, cb := level(r), level(g), level(b)
+	cube := 16 + 36*cr + 6*cg + cb
+	cubeDist := sq(r-levelValue(cr)) + sq(g-levelValue(cg)) + sq(b-levelValue(cb))
+
+	// Nearest step on the gray ramp (8, 18, ..., 238)
+	avg := (r + g + b) / 3
+	step := min(23, max(0, (avg-3)/10))
+	grayValue := 8 + step*10
+	grayDist := sq(r-grayValue) + sq(g-grayValue) + sq(b-grayValue)
+
+	if grayDist < cubeDist {
+		return 232 + step
+	}
+	return cube
+}
+
+// sq returns v squared
+func sq(v int) int {
+	return v * v
+}
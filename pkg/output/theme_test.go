@@ -0,0 +1,116 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestParseStyle(t *testing.T) {
+	defer func(noColor bool) { color.NoColor = noColor }(color.NoColor)
+	color.NoColor = false
+
+	tests := []struct {
+		spec      string
+		trueColor bool
+		want      string
+	}{
+		{"", false, "x"},
+		{"none", false, "x"},
+		{"bold green", false, "\x1b[1;32mx"},
+		{"bright-blue", false, "\x1b[94mx"},
+		{"gray", false, "\x1b[90mx"},
+		{"black on-bright-yellow", false, "\x1b[30;103mx"},
+		{"208", false, "\x1b[38;5;208mx"},
+		{"on-17", false, "\x1b[48;5;17mx"},
+		{"#ff8800", true, "\x1b[38;2;255;136;0mx"},
+		{"#ff8800", false, "\x1b[38;5;208mx"},
+		{"Italic Underline", false, "\x1b[3;4mx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			style, err := ParseStyle(tt.spec, tt.trueColor)
+			if err != nil {
+				t.Fatalf("ParseStyle(%q) error = %v", tt.spec, err)
+			}
+			got := style("x")
+			// Compare up to the text; reset sequences are fatih/color's business
+			if len(got) < len(tt.want) || got[:len(tt.want)] != tt.want {
+				t.Errorf("ParseStyle(%q)(\"x\") = %q, want prefix %q", tt.spec, got, tt.want)
+			}
+		})
+	}
+
+	for _, spec := range []string{"purple", "bright-208", "256", "#12345", "#gggggg"} {
+		if _, err := ParseStyle(spec, false); err == nil {
+			t.Errorf("ParseStyle(%q) succeeded, want an error", spec)
+		}
+	}
+}
+
+func TestRGBTo256(t *testing.T) {
+	tests := []struct {
+		r, g, b int
+		want    int
+	}{
+		{0, 0, 0, 16},
+		{255, 255, 255, 231},
+		{255, 0, 0, 196},
+		{255, 136, 0, 208},
+		{128, 128, 128, 244},
+		{95, 135, 175, 67},
+	}
+	for _, tt := range tests {
+		if got := RGBTo256(tt.r, tt.g, tt.b); got != tt.want {
+			t.Errorf("RGBTo256(%d, %d, %d) = %d, want %d", tt.r, tt.g, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestApplyTheme(t *testing.T) {
+	defer func(noColor bool) {
+		color.NoColor = noColor
+		_ = ApplyTheme(DefaultTheme, nil)
+	}(color.NoColor)
+	color.NoColor = false
+
+	// Every built-in theme defines every role with a valid style
+	for _, name := range ThemeNames() {
+		if err := ApplyTheme(name, nil); err != nil {
+			t.Errorf("ApplyTheme(%q) error = %v", name, err)
+		}
+		_, theme := ActiveTheme()
+		for _, role := range Roles {
+			if _, ok := theme[role]; !ok {
+				t.Errorf("theme %q is missing role %q", name, role)
+			}
+		}
+	}
+
+	if err := ApplyTheme("dark", map[string]string{RoleTaskOpen: "red", RolePast: ""}); err != nil {
+		t.Fatalf("ApplyTheme() with overrides error = %v", err)
+	}
+	if got := TaskOpen("[ ]"); got != "\x1b[31m[ ]\x1b[0m" {
+		t.Errorf("TaskOpen() = %q after override", got)
+	}
+	if got := DateGray("2026-01-10"); got != "2026-01-10" {
+		t.Errorf("DateGray() = %q, want an empty override to disable the color", got)
+	}
+	if got := DateBlue("x"); got != "\x1b[94mx\x1b[0m" {
+		t.Errorf("DateBlue() = %q, want the dark theme's future color", got)
+	}
+
+	// Invalid themes and overrides leave the active theme alone
+	for _, overrides := range []map[string]string{{"bogus": "red"}, {RoleTag: "purple"}} {
+		if err := ApplyTheme("light", overrides); err == nil {
+			t.Errorf("ApplyTheme(light, %v) succeeded, want an error", overrides)
+		}
+	}
+	if err := ApplyTheme("neon", nil); err == nil {
+		t.Error("ApplyTheme(neon) succeeded, want an error")
+	}
+	if name, _ := ActiveTheme(); name != "dark" {
+		t.Errorf("active theme = %q after failed applies, want dark", name)
+	}
+}
@@ -0,0 +1,111 @@
+package pager
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// command is the pager used by Print; paging is off until Configure is called
+var command string
+
+// Configure sets the pager command for this process; empty disables paging
+func Configure(pagerCommand string) {
+	command = pagerCommand
+}
+
+// Print writes content to stdout, through the pager when stdout is a terminal
+// and the content doesn't fit on the screen
+func Print(content string) {
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	if disabled(command) || !installed(command) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		fmt.Print(content)
+		return
+	}
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || !needsPaging(content, width, height) {
+		fmt.Print(content)
+		return
+	}
+
+	argv := shellCommand(command)
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	// Keep colors and the output on screen when less is used without options
+	if os.Getenv("LESS") == "" {
+		cmd.Env = append(os.Environ(), "LESS=FRX")
+	}
+
+	// Fall back to plain output if the pager can't be started
+	if err := cmd.Start(); err != nil {
+		fmt.Print(content)
+		return
+	}
+
+	// Ctrl+C is for the pager (less uses it to stop a search), so it mustn't kill us
+	// and leave the pager behind. Ignored only after Start, so the pager doesn't inherit it
+	if !signal.Ignored(os.Interrupt) {
+		signal.Ignore(os.Interrupt)
+		defer signal.Reset(os.Interrupt)
+	}
+	_ = cmd.Wait()
+}
+
+// disabled reports whether a pager command means "don't page"
+func disabled(pagerCommand string) bool {
+	pagerCommand = strings.TrimSpace(pagerCommand)
+	return pagerCommand == "" || pagerCommand == "cat"
+}
+
+// installed reports whether the pager's program can be found, so a missing pager
+// falls back to plain output instead of losing it
+func installed(pagerCommand string) bool {
+	fields := strings.Fields(pagerCommand)
+	if len(fields) == 0 {
+		return false
+	}
+	_, err := exec.LookPath(fields[0])
+	return err == nil
+}
+
+// ansiEscape matches SGR color sequences
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// needsPaging reports whether content takes more rows than the screen has,
+// leaving one row for the shell prompt
+func needsPaging(content string, width, height int) bool {
+	if width <= 0 || height <= 0 {
+		return false
+	}
+
+	rows := 0
+	for _, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		length := len([]rune(ansiEscape.ReplaceAllString(line, "")))
+		// Long lines wrap onto extra rows
+		rows += max(1, (length+width-1)/width)
+		if rows >= height {
+			return true
+		}
+	}
+	return false
+}
+
+// shellCommand returns the argv that runs a pager command through the shell,
+// so pagers configured with options (like "less -R") work as written
+func shellCommand(pagerCommand string) []string {
+	if runtime.GOOS == "windows" {
+		return []string{"cmd", "/C", pagerCommand}
+	}
+	return []string{"sh", "-c", pagerCommand}
+}
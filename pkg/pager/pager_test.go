@@ -0,0 +1,48 @@
+package pager
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNeedsPaging(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		width   int
+		height  int
+		want    bool
+	}{
+		{"fits", "one\ntwo\nthree\n", 80, 24, false},
+		{"leaves a row for the prompt", strings.Repeat("line\n", 23), 80, 24, false},
+		{"taller than the screen", strings.Repeat("line\n", 24), 80, 24, true},
+		{"wrapped lines count", strings.Repeat(strings.Repeat("x", 100)+"\n", 12), 80, 24, true},
+		{"color codes don't count", strings.Repeat("\x1b[32m"+strings.Repeat("x", 80)+"\x1b[0m\n", 23), 80, 24, false},
+		{"unknown size", strings.Repeat("line\n", 100), 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := needsPaging(tt.content, tt.width, tt.height); got != tt.want {
+				t.Errorf("needsPaging() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstalled(t *testing.T) {
+	if !installed("sh -c true") {
+		t.Error("installed(\"sh -c true\") = false, want true")
+	}
+	if installed("plan-test-missing-pager -R") {
+		t.Error("installed() = true for a missing program")
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	for command, want := range map[string]bool{"": true, " ": true, "cat": true, "less -R": false, "more": false} {
+		if got := disabled(command); got != want {
+			t.Errorf("disabled(%q) = %v, want %v", command, got, want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package planfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeJournal fills dir with a synthetic 20-year journal: 240 month files with
+// a day section for every day, each with a titled header and 8 lines
+// The files are aged past indexRacyWindow so the index can be reused
+func writeJournal(b *testing.B, dir string) {
+	b.Helper()
+	old := time.Now().Add(-time.Hour)
+	for year := 2006; year < 2026; year++ {
+		for month := time.January; month <= time.December; month++ {
+			var sb strings.Builder
+			fmt.Fprintf(&sb, "# %d-%02d\n\nMonthly goals and notes\n\n", year, month)
+			day := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+			for ; day.Month() == month; day = day.AddDate(0, 0, 1) {
+				fmt.Fprintf(&sb, "## %s - Day %d\n", day.Format("2006-01-02"), day.YearDay())
+				sb.WriteString("* [x] Review the inbox #admin\n")
+				sb.WriteString("* [ ] Write the weekly report\n")
+				sb.WriteString("* [>] Plan the next sprint\n")
+				sb.WriteString("  Notes about the planning meeting\n")
+				sb.WriteString("* Met with the team about (#project/alpha)\n")
+				sb.WriteString("* [-] Cancelled the offsite\n")
+				sb.WriteString("* Read two chapters\n")
+				sb.WriteString("\n")
+			}
+
+			path := filepath.Join(dir, fmt.Sprintf("%d-%02d.plan", year, month))
+			if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+				b.Fatal(err)
+			}
+			if err := os.Chtimes(path, old, old); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkDiscoverDays compares reading a whole journal one file at a time, with
+// the worker pool, and from a warm index
+func BenchmarkDiscoverDays(b *testing.B) {
+	store := &Store{Dir: b.TempDir()}
+	writeJournal(b, store.Dir)
+	indexPath := filepath.Join(store.Dir, IndexFileName)
+
+	cold := func(workers int) func(b *testing.B) {
+		return func(b *testing.B) {
+			defer func(n int) { parseWorkers = n }(parseWorkers)
+			parseWorkers = workers
+			for b.Loop() {
+				os.Remove(indexPath)
+				if _, err := store.DiscoverDays(""); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+	b.Run("sequential", cold(1))
+	b.Run("parallel", cold(parseWorkers))
+
+	b.Run("indexed", func(b *testing.B) {
+		if _, _, err := store.Reindex(); err != nil {
+			b.Fatal(err)
+		}
+		for b.Loop() {
+			if _, err := store.DiscoverDays(""); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkDiscoverDates compares reading the dates of a journal without an index by
+// parsing every file in full, one at a time, against scanning only the headers with the
+// worker pool
+func BenchmarkDiscoverDates(b *testing.B) {
+	store := &Store{Dir: b.TempDir()}
+	writeJournal(b, store.Dir)
+
+	refresh := func(workers int, headersOnly bool) func(b *testing.B) {
+		return func(b *testing.B) {
+			defer func(n int) { parseWorkers = n }(parseWorkers)
+			parseWorkers = workers
+			for b.Loop() {
+				ix := &index{Version: indexVersion, Files: map[string]indexEntry{}}
+				if _, _, err := store.refreshIndex("", ix, false, headersOnly); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+	b.Run("full-sequential", refresh(1, false))
+	b.Run("headers-parallel", refresh(parseWorkers, true))
+}
+
+// BenchmarkParsePlan compares a full parse of one month against a header-only scan
+func BenchmarkParsePlan(b *testing.B) {
+	dir := b.TempDir()
+	writeJournal(b, dir)
+	data, err := os.ReadFile(filepath.Join(dir, "2025-01.plan"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	content := string(data)
+
+	b.Run("full", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			parsePlan(content, false)
+		}
+	})
+	b.Run("headers", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			parsePlan(content, true)
+		}
+	})
+}
+
+// BenchmarkHeaderDate compares the header matcher with the regexp the parser used to
+// compile for every date header
+func BenchmarkHeaderDate(b *testing.B) {
+	const text = "2026-02-13 - Planning day"
+
+	b.Run("regexp", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})`).FindStringSubmatch(text)
+		}
+	})
+	b.Run("precompiled", func(b *testing.B) {
+		b.ReportAllocs()
+		pattern := regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})`)
+		for b.Loop() {
+			pattern.FindStringSubmatch(text)
+		}
+	})
+	b.Run("hand-rolled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			headerDate(text)
+		}
+	})
+}
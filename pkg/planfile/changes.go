@@ -0,0 +1,126 @@
+package planfile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+)
+
+// HashFile returns the SHA-256 hash of a file's content
+// Returns empty string if the file does not exist
+func (s *Store) HashFile(filePath string) (string, error) {
+	content, err := s.readPlan(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// DateSectionLines returns the content lines for a date, without trailing empty lines
+// Returns nil if the date section does not exist
+func (s *Store) DateSectionLines(filePath, date string) ([]string, error) {
+	pf, err := s.ParseFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	content, ok := pf.Dates[date]
+	if !ok {
+		return nil, nil
+	}
+	return trimTrailingEmptyLines(content), nil
+}
+
+// FileLines returns all lines of a file, without trailing empty lines
+// Returns nil if the file does not exist
+func (s *Store) FileLines(filePath string) ([]string, error) {
+	content, err := s.readPlan(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return trimTrailingEmptyLines(strings.Split(string(content), "\n")), nil
+}
+
+// DiffLines counts lines added and removed between two versions of a section
+// Uses a longest common subsequence so moved or edited lines are counted once each way
+func DiffLines(before, after []string) (added, removed int) {
+	// lcs[i][j] is the LCS length of before[i:] and after[j:]
+	lcs := make([][]int, len(before)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	common := lcs[0][0]
+	return len(after) - common, len(before) - common
+}
+
+// FormatChangeSummary returns a short summary such as "2026-02-13: +5 -1 lines"
+func FormatChangeSummary(date string, added, removed int) string {
+	return fmt.Sprintf("%s: +%d -%d lines", date, added, removed)
+}
+
+// RemoveDateIfEmpty removes a date section whose content is only blank lines
+// Returns true if the section was removed
+func (s *Store) RemoveDateIfEmpty(date time.Time) (bool, error) {
+	unlock, err := s.lockDirectory(s.Dir)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	filePath := filepath.Join(s.Dir, dateutil.MonthFileName(date))
+	pf, err := s.ParseFile(filePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	dateStr := dateutil.FormatDate(date)
+	content, exists := pf.Dates[dateStr]
+	if !exists {
+		return false, nil
+	}
+	for _, line := range content {
+		if strings.TrimSpace(line) != "" {
+			return false, nil
+		}
+	}
+
+	// Keep a header that carries a title, since that is content too
+	if header, ok := pf.DateHeaders[dateStr]; ok && strings.TrimSpace(header) != "## "+dateStr {
+		return false, nil
+	}
+
+	delete(pf.Dates, dateStr)
+	delete(pf.DateHeaders, dateStr)
+	order := pf.DateOrder[:0]
+	for _, d := range pf.DateOrder {
+		if d != dateStr {
+			order = append(order, d)
+		}
+	}
+	pf.DateOrder = order
+
+	return true, s.WritePlanFile(filePath, pf)
+}
@@ -0,0 +1,137 @@
+package planfile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name        string
+		before      []string
+		after       []string
+		wantAdded   int
+		wantRemoved int
+	}{
+		{
+			name:      "new section",
+			before:    nil,
+			after:     []string{"* one", "* two"},
+			wantAdded: 2,
+		},
+		{
+			name:      "appended line",
+			before:    []string{"* one"},
+			after:     []string{"* one", "* two"},
+			wantAdded: 1,
+		},
+		{
+			name:        "edited line",
+			before:      []string{"* one", "* two"},
+			after:       []string{"* one", "* 2"},
+			wantAdded:   1,
+			wantRemoved: 1,
+		},
+		{
+			name:        "removed everything",
+			before:      []string{"* one", "* two"},
+			after:       []string{},
+			wantRemoved: 2,
+		},
+		{
+			name:   "unchanged",
+			before: []string{"* one"},
+			after:  []string{"* one"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := DiffLines(tt.before, tt.after)
+			if added != tt.wantAdded || removed != tt.wantRemoved {
+				t.Errorf("DiffLines() = +%d -%d, want +%d -%d", added, removed, tt.wantAdded, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestRemoveDateIfEmpty(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
+	testFile := filepath.Join(tmpDir, "2026-02.plan")
+
+	content := `# 2026-02
+
+## 2026-02-13
+* Entry 1
+
+
+## 2026-02-14
+
+
+## 2026-02-15 - Planning day
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	// Empty untitled section is removed
+	removed, err := store.RemoveDateIfEmpty(time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("RemoveDateIfEmpty() error = %v", err)
+	}
+	if !removed {
+		t.Error("RemoveDateIfEmpty() = false, want true for empty section")
+	}
+
+	// Section with content is kept
+	removed, err = store.RemoveDateIfEmpty(time.Date(2026, 2, 13, 0, 0, 0, 0, time.UTC))
+	if err != nil || removed {
+		t.Errorf("RemoveDateIfEmpty() = %v, %v, want false for section with content", removed, err)
+	}
+
+	// Titled section is kept
+	removed, err = store.RemoveDateIfEmpty(time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil || removed {
+		t.Errorf("RemoveDateIfEmpty() = %v, %v, want false for titled section", removed, err)
+	}
+
+	newContent, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if strings.Contains(string(newContent), "## 2026-02-14") {
+		t.Error("empty date header still present after RemoveDateIfEmpty()")
+	}
+	if !strings.Contains(string(newContent), "## 2026-02-15 - Planning day") {
+		t.Error("titled date header removed by RemoveDateIfEmpty()")
+	}
+}
+
+func TestHashFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
+	testFile := filepath.Join(tmpDir, "2026-02.plan")
+
+	missing, err := store.HashFile(testFile)
+	if err != nil || missing != "" {
+		t.Errorf("HashFile() on missing file = %q, %v, want empty", missing, err)
+	}
+
+	if err := os.WriteFile(testFile, []byte("# 2026-02\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	first, _ := store.HashFile(testFile)
+
+	if err := os.WriteFile(testFile, []byte("# 2026-02\n\n## 2026-02-13\n"), 0644); err != nil {
+		t.Fatalf("Failed to update test file: %v", err)
+	}
+	second, _ := store.HashFile(testFile)
+
+	if first == "" || first == second {
+		t.Errorf("HashFile() did not detect change: %q -> %q", first, second)
+	}
+}
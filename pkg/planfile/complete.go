@@ -0,0 +1,142 @@
+package planfile
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+)
+
+// Completion is a shell completion candidate with a short description
+type Completion struct {
+	Value       string
+	Description string
+}
+
+// dateKeywords are the relative targets accepted by dateutil.ParseTarget, with their day offsets
+var dateKeywords = []struct {
+	name   string
+	offset int
+}{
+	{"yesterday", -1},
+	{"today", 0},
+	{"tomorrow", 1},
+}
+
+// CompleteDates suggests date targets starting with prefix: the date keywords, months with
+// entries, and the days of a month once the prefix names one (YYYY-MM)
+func CompleteDates(days []DaySummary, prefix string, today time.Time) []Completion {
+	var completions []Completion
+	for _, keyword := range dateKeywords {
+		if strings.HasPrefix(keyword.name, prefix) {
+			date := today.AddDate(0, 0, keyword.offset)
+			completions = append(completions, Completion{keyword.name, date.Format("Mon 2006-01-02")})
+		}
+	}
+
+	completions = append(completions, completeMonths(days, prefix)...)
+
+	// Listing every day up front would flood the shell, so days appear once a month is typed
+	if len(prefix) < len("2006-01") || !dateutil.IsValidMonth(prefix[:len("2006-01")]) {
+		return completions
+	}
+	for _, day := range days {
+		if strings.HasPrefix(day.Date, prefix) {
+			completions = append(completions, Completion{day.Date, dayDescription(day)})
+		}
+	}
+	return completions
+}
+
+// CompleteFilters suggests year (YYYY) filters starting with prefix, and month (YYYY-MM)
+// filters too when withMonths is set
+func CompleteFilters(days []DaySummary, prefix string, withMonths bool) []Completion {
+	counts := make(map[string]int)
+	var years []string
+	for _, day := range days {
+		year := day.Date[:len("2006")]
+		if counts[year] == 0 {
+			years = append(years, year)
+		}
+		counts[year]++
+	}
+
+	var completions []Completion
+	for _, year := range years {
+		if strings.HasPrefix(year, prefix) {
+			completions = append(completions, Completion{year, entriesDescription(counts[year])})
+		}
+	}
+	if withMonths {
+		completions = append(completions, completeMonths(days, prefix)...)
+	}
+	return completions
+}
+
+// CompleteFiles suggests plan filenames in the plans directory starting with prefix
+func (s *Store) CompleteFiles(days []DaySummary, prefix string) ([]Completion, error) {
+	fileNames, err := s.planFileNames()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read plans directory: %w", err)
+	}
+
+	counts := monthCounts(days)
+	var completions []Completion
+	for _, fileName := range fileNames {
+		month := strings.TrimSuffix(fileName, ".plan")
+		if !strings.HasPrefix(fileName, prefix) {
+			continue
+		}
+		completions = append(completions, Completion{fileName, entriesDescription(counts[month])})
+	}
+	return completions, nil
+}
+
+// completeMonths suggests months with entries starting with prefix
+func completeMonths(days []DaySummary, prefix string) []Completion {
+	counts := monthCounts(days)
+	var completions []Completion
+	for _, day := range days {
+		month := day.Date[:len("2006-01")]
+		if counts[month] == 0 || !strings.HasPrefix(month, prefix) {
+			continue
+		}
+		completions = append(completions, Completion{month, entriesDescription(counts[month])})
+		counts[month] = 0 // Suggest each month once
+	}
+	return completions
+}
+
+// monthCounts returns the number of days per month (YYYY-MM)
+func monthCounts(days []DaySummary) map[string]int {
+	counts := make(map[string]int)
+	for _, day := range days {
+		counts[day.Date[:len("2006-01")]]++
+	}
+	return counts
+}
+
+// dayDescription returns the weekday and title of a day, e.g. "Fri - Offsite"
+func dayDescription(day DaySummary) string {
+	date, err := time.Parse("2006-01-02", day.Date)
+	if err != nil {
+		return day.Title
+	}
+	if day.Title == "" {
+		return date.Format("Mon")
+	}
+	return date.Format("Mon") + " - " + day.Title
+}
+
+// entriesDescription describes a number of entries, e.g. "3 entries"
+func entriesDescription(count int) string {
+	if count == 1 {
+		return "1 entry"
+	}
+	return fmt.Sprintf("%d entries", count)
+}
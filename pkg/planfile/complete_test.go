@@ -0,0 +1,107 @@
+package planfile
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func completionDays() []DaySummary {
+	return []DaySummary{
+		{Date: "2025-12-31", Lines: 1},
+		{Date: "2026-02-12", Title: "Offsite", Lines: 2},
+		{Date: "2026-02-13", Lines: 1},
+	}
+}
+
+func TestCompleteDates(t *testing.T) {
+	today := time.Date(2026, 2, 13, 9, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name   string
+		prefix string
+		want   []Completion
+	}{
+		{"keywords", "to", []Completion{
+			{"today", "Fri 2026-02-13"},
+			{"tomorrow", "Sat 2026-02-14"},
+		}},
+		{"months only before a month is typed", "2026", []Completion{
+			{"2026-02", "2 entries"},
+		}},
+		{"days of a typed month", "2026-02", []Completion{
+			{"2026-02", "2 entries"},
+			{"2026-02-12", "Thu - Offsite"},
+			{"2026-02-13", "Fri"},
+		}},
+		{"day prefix", "2026-02-13", []Completion{
+			{"2026-02-13", "Fri"},
+		}},
+		{"no match", "x", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CompleteDates(completionDays(), tt.prefix, today)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompleteDates(%q) = %v, want %v", tt.prefix, got, tt.want)
+			}
+		})
+	}
+
+	// An empty prefix offers every keyword and month
+	if got := CompleteDates(completionDays(), "", today); len(got) != 5 {
+		t.Errorf("CompleteDates(\"\") returned %d completions, want 5: %v", len(got), got)
+	}
+}
+
+func TestCompleteFilters(t *testing.T) {
+	got := CompleteFilters(completionDays(), "", true)
+	want := []Completion{
+		{"2025", "1 entry"},
+		{"2026", "2 entries"},
+		{"2025-12", "1 entry"},
+		{"2026-02", "2 entries"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompleteFilters() = %v, want %v", got, want)
+	}
+
+	got = CompleteFilters(completionDays(), "2026", false)
+	want = []Completion{{"2026", "2 entries"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompleteFilters(years only) = %v, want %v", got, want)
+	}
+}
+
+func TestCompleteFiles(t *testing.T) {
+	dir := t.TempDir()
+	store := &Store{Dir: dir}
+	for _, name := range []string{"2026-02.plan", "2026-03.plan", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "archive.plan"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.CompleteFiles(completionDays(), "2026")
+	if err != nil {
+		t.Fatalf("CompleteFiles() error = %v", err)
+	}
+	want := []Completion{
+		{"2026-02.plan", "2 entries"},
+		{"2026-03.plan", "0 entries"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompleteFiles() = %v, want %v", got, want)
+	}
+
+	missing := &Store{Dir: filepath.Join(dir, "missing")}
+	if got, err := missing.CompleteFiles(nil, ""); err != nil || got != nil {
+		t.Errorf("CompleteFiles() = %v, %v, want nil, nil", got, err)
+	}
+}
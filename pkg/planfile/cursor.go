@@ -0,0 +1,256 @@
+package planfile
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+)
+
+// Cursor modes control where the editor opens inside a date section
+const (
+	CursorEnd = "end" // End of the last entry
+	CursorNew = "new" // A new line after the last entry, seeded with a prefix
+	CursorTop = "top" // Top of the section, right below the header
+)
+
+// CursorPosition is where the editor should place the cursor (1-based)
+type CursorPosition struct {
+	Line   int
+	Column int
+	Seed   string // Text the CLI inserted at Line (empty if it only inserted a blank line)
+	Seeded bool   // Whether the CLI inserted a line that should be removed if left untouched
+
+	date   string // The date section the line was inserted into
+	header int    // The 0-based line of that section's header when the line was inserted
+}
+
+// NormalizeCursorMode validates a cursor mode, defaulting to CursorNew
+func NormalizeCursorMode(mode string) string {
+	normalized := strings.ToLower(strings.TrimSpace(mode))
+	switch normalized {
+	case CursorEnd, CursorNew, CursorTop:
+		return normalized
+	default:
+		return CursorNew
+	}
+}
+
+// ExpandSeed expands placeholders in a seed prefix and adds the separating space
+// Supported placeholders: %time% (HH:MM)
+func ExpandSeed(seed string, now time.Time) string {
+	seed = strings.TrimSpace(seed)
+	if seed == "" {
+		return ""
+	}
+	return strings.ReplaceAll(seed, "%time%", now.Format("15:04")) + " "
+}
+
+// PrepareCursor finds (and if needed creates) the line where the editor should open
+// The date section must already exist. For CursorNew, and for empty sections in any
+// mode, a line is inserted so the cursor never lands past the end of the file.
+func (s *Store) PrepareCursor(date time.Time, mode, seed string) (string, CursorPosition, error) {
+	filePath := filepath.Join(s.Dir, dateutil.MonthFileName(date))
+	pos, err := s.PrepareCursorInFile(filePath, dateutil.FormatDate(date), mode, seed)
+	if err != nil {
+		return "", CursorPosition{}, err
+	}
+	return filePath, pos, nil
+}
+
+// PrepareCursorInFile is PrepareCursor for any file containing the date section
+// (e.g. a temporary file holding a single day)
+func (s *Store) PrepareCursorInFile(filePath, dateStr, mode, seed string) (CursorPosition, error) {
+	unlock, err := s.lockDirectory(filepath.Dir(filePath))
+	if err != nil {
+		return CursorPosition{}, err
+	}
+	defer unlock()
+
+	content, err := s.readPlan(filePath)
+	if err != nil {
+		return CursorPosition{}, fmt.Errorf("failed to read file: %w", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+
+	headerIdx, lastContentIdx := findSection(lines, dateStr)
+	if headerIdx == -1 {
+		return CursorPosition{}, fmt.Errorf("date section %s not found", dateStr)
+	}
+
+	isEmpty := lastContentIdx == headerIdx
+	mode = NormalizeCursorMode(mode)
+
+	switch {
+	case mode == CursorEnd && !isEmpty:
+		lastLine := lines[lastContentIdx]
+		return CursorPosition{Line: lastContentIdx + 1, Column: utf8.RuneCountInString(lastLine) + 1}, nil
+
+	case mode == CursorTop && !isEmpty:
+		return CursorPosition{Line: headerIdx + 2, Column: 1}, nil
+	}
+
+	// Insert a new line after the last entry (seeded only in CursorNew mode)
+	insertText := ""
+	if mode == CursorNew {
+		insertText = seed
+	}
+	insertIdx := lastContentIdx + 1
+	lines = append(lines[:insertIdx], append([]string{insertText}, lines[insertIdx:]...)...)
+
+	if err := s.writeLines(filePath, lines); err != nil {
+		return CursorPosition{}, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return CursorPosition{
+		Line:   insertIdx + 1,
+		Column: utf8.RuneCountInString(insertText) + 1,
+		Seed:   insertText,
+		Seeded: true,
+		date:   dateStr,
+		header: headerIdx,
+	}, nil
+}
+
+// LocateCursor finds where the editor should open inside a date section without
+// modifying the file. Empty sections open on the header line.
+func (s *Store) LocateCursor(filePath, dateStr, mode string) (CursorPosition, error) {
+	lines, err := s.readLines(filePath)
+	if err != nil {
+		return CursorPosition{}, err
+	}
+
+	headerIdx, lastContentIdx := findSection(lines, dateStr)
+	if headerIdx == -1 {
+		return CursorPosition{}, fmt.Errorf("date section %s not found", dateStr)
+	}
+
+	if lastContentIdx == headerIdx {
+		return CursorPosition{Line: headerIdx + 1, Column: utf8.RuneCountInString(lines[headerIdx]) + 1}, nil
+	}
+	if NormalizeCursorMode(mode) == CursorTop {
+		return CursorPosition{Line: headerIdx + 2, Column: 1}, nil
+	}
+	return CursorPosition{Line: lastContentIdx + 1, Column: utf8.RuneCountInString(lines[lastContentIdx]) + 1}, nil
+}
+
+// MonthCursor finds where the editor should open for a whole month file
+// CursorTop opens at the preamble (or below the month header), other modes at the end of the file
+func (s *Store) MonthCursor(filePath, mode string) (CursorPosition, error) {
+	lines, err := s.readLines(filePath)
+	if err != nil {
+		return CursorPosition{}, err
+	}
+
+	if NormalizeCursorMode(mode) == CursorTop {
+		for i, line := range lines {
+			if !strings.HasPrefix(line, "# ") {
+				continue
+			}
+			// The first non-empty line after the month header is the preamble, unless it's a day
+			for j := i + 1; j < len(lines); j++ {
+				if lines[j] == "" {
+					continue
+				}
+				if !strings.HasPrefix(lines[j], "## ") {
+					return CursorPosition{Line: j + 1, Column: 1}, nil
+				}
+				break
+			}
+			return CursorPosition{Line: min(i+2, len(lines)), Column: 1}, nil
+		}
+		return CursorPosition{Line: 1, Column: 1}, nil
+	}
+
+	last := len(lines) - 1
+	for last > 0 && lines[last] == "" {
+		last--
+	}
+	return CursorPosition{Line: last + 1, Column: utf8.RuneCountInString(lines[last]) + 1}, nil
+}
+
+// findSection returns the 0-based index of a date header and of the section's last
+// non-empty line (the header itself for empty sections), or -1 if the date is missing
+func findSection(lines []string, dateStr string) (headerIdx, lastContentIdx int) {
+	headerIdx, lastContentIdx, _ = findSectionBounds(lines, dateStr)
+	return headerIdx, lastContentIdx
+}
+
+// findSectionBounds is findSection that also returns the index just past the section
+// (the next date header, or the end of the file)
+func findSectionBounds(lines []string, dateStr string) (headerIdx, lastContentIdx, end int) {
+	headerIdx = -1
+	lastContentIdx = -1
+	end = len(lines)
+	for i, line := range lines {
+		if headerIdx == -1 {
+			if strings.HasPrefix(line, "## "+dateStr) {
+				headerIdx = i
+				lastContentIdx = i
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "## ") {
+			end = i
+			break
+		}
+		if line != "" {
+			lastContentIdx = i
+		}
+	}
+	return headerIdx, lastContentIdx, end
+}
+
+// readLines reads a file as lines, ignoring the final newline
+func (s *Store) readLines(filePath string) ([]string, error) {
+	content, err := s.readPlan(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n"), nil
+}
+
+// RemoveUntouchedSeed removes the line inserted by PrepareCursor if the user left it unchanged
+// The line is only removed while it is still inside its date section and the section
+// hasn't moved; a blank seed must still directly follow the section's last entry.
+// Returns true if the line was removed
+func (s *Store) RemoveUntouchedSeed(filePath string, pos CursorPosition) (bool, error) {
+	if !pos.Seeded {
+		return false, nil
+	}
+
+	unlock, err := s.lockDirectory(filepath.Dir(filePath))
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	content, err := s.readPlan(filePath)
+	if err != nil {
+		return false, err
+	}
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+
+	idx := pos.Line - 1
+	headerIdx, lastContentIdx, end := findSectionBounds(lines, pos.date)
+	if headerIdx == -1 || headerIdx != pos.header || idx <= headerIdx || idx >= end {
+		return false, nil
+	}
+	if strings.TrimSpace(lines[idx]) != strings.TrimSpace(pos.Seed) {
+		return false, nil
+	}
+	if strings.TrimSpace(pos.Seed) == "" && idx != lastContentIdx+1 {
+		return false, nil
+	}
+
+	lines = append(lines[:idx], lines[idx+1:]...)
+	return true, s.writeLines(filePath, lines)
+}
+
+// writeLines rewrites a file with the given lines (existing permissions are kept)
+func (s *Store) writeLines(filePath string, lines []string) error {
+	return s.writePlan(filePath, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+}
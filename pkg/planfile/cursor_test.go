@@ -0,0 +1,309 @@
+package planfile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const cursorTestContent = `# 2026-02
+
+## 2026-02-13
+* Entry 1
+* Entry two
+
+
+## 2026-02-14
+`
+
+func TestPrepareCursor(t *testing.T) {
+	tests := []struct {
+		name       string
+		date       time.Time
+		mode       string
+		seed       string
+		wantLine   int
+		wantColumn int
+		wantSeeded bool
+	}{
+		{
+			name:       "end of last entry",
+			date:       time.Date(2026, 2, 13, 0, 0, 0, 0, time.UTC),
+			mode:       CursorEnd,
+			wantLine:   5,
+			wantColumn: 12,
+		},
+		{
+			name:       "top of section",
+			date:       time.Date(2026, 2, 13, 0, 0, 0, 0, time.UTC),
+			mode:       CursorTop,
+			wantLine:   4,
+			wantColumn: 1,
+		},
+		{
+			name:       "new seeded line",
+			date:       time.Date(2026, 2, 13, 0, 0, 0, 0, time.UTC),
+			mode:       CursorNew,
+			seed:       "- ",
+			wantLine:   6,
+			wantColumn: 3,
+			wantSeeded: true,
+		},
+		{
+			name:       "empty section at end of file gets a real line",
+			date:       time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC),
+			mode:       CursorEnd,
+			wantLine:   9,
+			wantColumn: 1,
+			wantSeeded: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			store := &Store{Dir: tmpDir}
+			testFile := filepath.Join(tmpDir, "2026-02.plan")
+			if err := os.WriteFile(testFile, []byte(cursorTestContent), 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+
+			filePath, pos, err := store.PrepareCursor(tt.date, tt.mode, tt.seed)
+			if err != nil {
+				t.Fatalf("PrepareCursor() error = %v", err)
+			}
+			if filePath != testFile {
+				t.Errorf("filePath = %v, want %v", filePath, testFile)
+			}
+			if pos.Line != tt.wantLine || pos.Column != tt.wantColumn || pos.Seeded != tt.wantSeeded {
+				t.Errorf("PrepareCursor() = %+v, want line %d column %d seeded %v", pos, tt.wantLine, tt.wantColumn, tt.wantSeeded)
+			}
+
+			// The cursor line must exist in the file
+			content, _ := os.ReadFile(testFile)
+			lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+			if pos.Line > len(lines) {
+				t.Errorf("cursor line %d is past the end of the file (%d lines)", pos.Line, len(lines))
+			}
+			if tt.seed != "" && lines[pos.Line-1] != tt.seed {
+				t.Errorf("seeded line = %q, want %q", lines[pos.Line-1], tt.seed)
+			}
+
+			// Untouched seed is removed and the file is restored
+			if pos.Seeded {
+				removed, err := store.RemoveUntouchedSeed(testFile, pos)
+				if err != nil || !removed {
+					t.Errorf("RemoveUntouchedSeed() = %v, %v, want true", removed, err)
+				}
+				restored, _ := os.ReadFile(testFile)
+				if string(restored) != cursorTestContent {
+					t.Errorf("file after RemoveUntouchedSeed() = %q, want original", restored)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveUntouchedSeedKeepsEdits(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
+	testFile := filepath.Join(tmpDir, "2026-02.plan")
+	if err := os.WriteFile(testFile, []byte(cursorTestContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	_, pos, err := store.PrepareCursor(time.Date(2026, 2, 13, 0, 0, 0, 0, time.UTC), CursorNew, "- ")
+	if err != nil {
+		t.Fatalf("PrepareCursor() error = %v", err)
+	}
+
+	// Simulate the user typing after the prefix
+	content, _ := os.ReadFile(testFile)
+	edited := strings.Replace(string(content), "\n- \n", "\n- wrote something\n", 1)
+	if err := os.WriteFile(testFile, []byte(edited), 0644); err != nil {
+		t.Fatalf("Failed to write edit: %v", err)
+	}
+
+	removed, err := store.RemoveUntouchedSeed(testFile, pos)
+	if err != nil || removed {
+		t.Errorf("RemoveUntouchedSeed() = %v, %v, want false for edited line", removed, err)
+	}
+}
+
+func TestRemoveUntouchedSeedAfterSectionMoved(t *testing.T) {
+	date := time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		edit func(string) string
+	}{
+		{
+			// The blank seed is now inside the new entries, not after them
+			name: "entries written after the blank line",
+			edit: func(content string) string {
+				return content + "\n* Added below\n"
+			},
+		},
+		{
+			// A day added above moves the section, so the recorded line is another one
+			name: "section moved down",
+			edit: func(content string) string {
+				return strings.Replace(content, "## 2026-02-13\n", "## 2026-02-12\n* Earlier\n\n## 2026-02-13\n", 1)
+			},
+		},
+		{
+			name: "section removed",
+			edit: func(content string) string {
+				return strings.Replace(content, "## 2026-02-14\n", "", 1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			store := &Store{Dir: tmpDir}
+			testFile := filepath.Join(tmpDir, "2026-02.plan")
+			if err := os.WriteFile(testFile, []byte(cursorTestContent), 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+
+			// An empty section gets a blank line even without a seed
+			_, pos, err := store.PrepareCursor(date, CursorNew, "")
+			if err != nil || !pos.Seeded {
+				t.Fatalf("PrepareCursor() = %+v, %v, want a seeded line", pos, err)
+			}
+
+			content, _ := os.ReadFile(testFile)
+			edited := tt.edit(string(content))
+			if err := os.WriteFile(testFile, []byte(edited), 0644); err != nil {
+				t.Fatalf("Failed to write edit: %v", err)
+			}
+
+			removed, err := store.RemoveUntouchedSeed(testFile, pos)
+			if err != nil || removed {
+				t.Errorf("RemoveUntouchedSeed() = %v, %v, want false", removed, err)
+			}
+			if after, _ := os.ReadFile(testFile); string(after) != edited {
+				t.Errorf("file changed:\n%s\nwant:\n%s", after, edited)
+			}
+		})
+	}
+}
+
+func TestExpandSeed(t *testing.T) {
+	now := time.Date(2026, 2, 13, 9, 5, 0, 0, time.UTC)
+	tests := map[string]string{
+		"":             "",
+		"-":            "- ",
+		"- ":           "- ",
+		"%time%":       "09:05 ",
+		"- [ ] %time%": "- [ ] 09:05 ",
+	}
+	for seed, want := range tests {
+		if got := ExpandSeed(seed, now); got != want {
+			t.Errorf("ExpandSeed(%q) = %q, want %q", seed, got, want)
+		}
+	}
+}
+
+func TestLocateCursorDoesNotModify(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
+	testFile := filepath.Join(tmpDir, "2026-02.plan")
+	if err := os.WriteFile(testFile, []byte(cursorTestContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		date       string
+		mode       string
+		wantLine   int
+		wantColumn int
+	}{
+		{"new mode opens at end of last entry", "2026-02-13", CursorNew, 5, 12},
+		{"top of section", "2026-02-13", CursorTop, 4, 1},
+		{"empty section opens on header", "2026-02-14", CursorNew, 8, 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos, err := store.LocateCursor(testFile, tt.date, tt.mode)
+			if err != nil {
+				t.Fatalf("LocateCursor() error = %v", err)
+			}
+			if pos.Line != tt.wantLine || pos.Column != tt.wantColumn || pos.Seeded {
+				t.Errorf("LocateCursor() = %+v, want line %d column %d", pos, tt.wantLine, tt.wantColumn)
+			}
+		})
+	}
+
+	if _, err := store.LocateCursor(testFile, "2026-02-20", CursorNew); err == nil {
+		t.Error("LocateCursor() expected error for missing date")
+	}
+
+	content, _ := os.ReadFile(testFile)
+	if string(content) != cursorTestContent {
+		t.Errorf("file modified by LocateCursor(): %q", content)
+	}
+}
+
+func TestMonthCursor(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		mode       string
+		wantLine   int
+		wantColumn int
+	}{
+		{
+			name:       "end of file",
+			content:    cursorTestContent,
+			mode:       CursorEnd,
+			wantLine:   8,
+			wantColumn: 14,
+		},
+		{
+			name:       "top without preamble",
+			content:    cursorTestContent,
+			mode:       CursorTop,
+			wantLine:   2,
+			wantColumn: 1,
+		},
+		{
+			name:       "top with preamble",
+			content:    "# 2026-02\n\nMonthly goals\n\n## 2026-02-13\n* Entry\n",
+			mode:       CursorTop,
+			wantLine:   3,
+			wantColumn: 1,
+		},
+		{
+			name:       "header only",
+			content:    "# 2026-02\n",
+			mode:       CursorTop,
+			wantLine:   1,
+			wantColumn: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &Store{Dir: t.TempDir()}
+			testFile := filepath.Join(store.Dir, "2026-02.plan")
+			if err := os.WriteFile(testFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to create test file: %v", err)
+			}
+
+			pos, err := store.MonthCursor(testFile, tt.mode)
+			if err != nil {
+				t.Fatalf("MonthCursor() error = %v", err)
+			}
+			if pos.Line != tt.wantLine || pos.Column != tt.wantColumn {
+				t.Errorf("MonthCursor() = %+v, want line %d column %d", pos, tt.wantLine, tt.wantColumn)
+			}
+		})
+	}
+}
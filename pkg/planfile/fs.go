@@ -0,0 +1,214 @@
+package planfile
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/abyss/plan-journal-cli/pkg/vault"
+)
+
+// Plan files are read and written through a Store rather than the os package, so a
+// plans directory can live on another filesystem: an in-memory tree (see MemFS), an
+// archive, or a read-only snapshot (os.DirFS). Paths under the Store's Dir go to its
+// filesystem, and all other paths (such as temporary copies for editors) go to the OS
+
+// ErrReadOnly is returned when writing to a Store whose filesystem isn't a WritableFS
+var ErrReadOnly = errors.New("plans directory is read-only")
+
+// WritableFS is a filesystem that plan files can also be written to
+// Names are slash-separated and relative to the filesystem's root, as in io/fs
+type WritableFS interface {
+	fs.FS
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	MkdirAll(name string, perm fs.FileMode) error
+	Rename(oldname, newname string) error
+	Remove(name string) error
+}
+
+// Store is a plans directory and the filesystem it is on
+// A Store caches the keyring of an encrypted directory, so it must not be copied after use
+type Store struct {
+	Dir string // The plans directory
+	FS  fs.FS  // Holds the files under Dir at its root; nil for the OS. Writes need a WritableFS
+
+	// Passphrase is asked the first time an encrypted file is read or written, unless
+	// Unlock was called. Without it, encrypted files fail with ErrNoPassphrase
+	Passphrase func() (string, error)
+
+	keyMu sync.Mutex
+	key   *keyringResult // The unlocked keyring or the unlock error, once known
+}
+
+// resolve returns the Store's filesystem and a path's name in it
+// ok is false for paths that are on the OS filesystem
+func (s *Store) resolve(path string) (fsys fs.FS, name string, ok bool) {
+	if s.FS == nil {
+		return nil, "", false
+	}
+	dir, err := filepath.Abs(s.Dir)
+	if err != nil {
+		return nil, "", false
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, "", false
+	}
+	rel, err := filepath.Rel(dir, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, "", false
+	}
+	return s.FS, filepath.ToSlash(rel), true
+}
+
+// writable returns the filesystem a path is on if it can be written
+func writable(fsys fs.FS, op, path string) (WritableFS, error) {
+	w, ok := fsys.(WritableFS)
+	if !ok {
+		return nil, &fs.PathError{Op: op, Path: path, Err: ErrReadOnly}
+	}
+	return w, nil
+}
+
+// readFile reads a file from the filesystem its path is on
+func (s *Store) readFile(path string) ([]byte, error) {
+	if fsys, name, ok := s.resolve(path); ok {
+		return fs.ReadFile(fsys, name)
+	}
+	return os.ReadFile(path)
+}
+
+// statFile returns file info from the filesystem a path is on
+func (s *Store) statFile(path string) (fs.FileInfo, error) {
+	if fsys, name, ok := s.resolve(path); ok {
+		return fs.Stat(fsys, name)
+	}
+	return os.Stat(path)
+}
+
+// readDir lists a directory on the filesystem its path is on, sorted by name
+func (s *Store) readDir(path string) ([]fs.DirEntry, error) {
+	if fsys, name, ok := s.resolve(path); ok {
+		return fs.ReadDir(fsys, name)
+	}
+	return os.ReadDir(path)
+}
+
+// writeFile writes a file on the filesystem its path is on
+func (s *Store) writeFile(path string, data []byte, perm fs.FileMode) error {
+	if fsys, name, ok := s.resolve(path); ok {
+		w, err := writable(fsys, "write", path)
+		if err != nil {
+			return err
+		}
+		return w.WriteFile(name, data, perm)
+	}
+	return os.WriteFile(path, data, perm)
+}
+
+// tempSeq numbers the temporary files replaceFile writes on other filesystems
+var tempSeq atomic.Uint64
+
+// replaceFile writes a file through a temporary file that is renamed over it, so readers
+// (and a crash) see either the old or the new content, never part of a write
+// On the OS filesystem, the file a symlink points to is replaced and an existing file
+// keeps its permissions, as with a write in place
+func (s *Store) replaceFile(path string, data []byte, perm fs.FileMode) error {
+	if _, _, ok := s.resolve(path); ok {
+		tmp := path + "." + strconv.FormatUint(tempSeq.Add(1), 10) + ".tmp"
+		if err := s.writeFile(tmp, data, perm); err != nil {
+			return err
+		}
+		if err := s.renameFile(tmp, path); err != nil {
+			s.removeFile(tmp)
+			return err
+		}
+		return nil
+	}
+
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Chmod(perm)
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// mkdirAll creates a directory and its parents on the filesystem its path is on
+func (s *Store) mkdirAll(path string, perm fs.FileMode) error {
+	if fsys, name, ok := s.resolve(path); ok {
+		w, err := writable(fsys, "mkdir", path)
+		if err != nil {
+			return err
+		}
+		return w.MkdirAll(name, perm)
+	}
+	return os.MkdirAll(path, perm)
+}
+
+// renameFile renames a file within the filesystem both paths are on
+func (s *Store) renameFile(oldpath, newpath string) error {
+	if fsys, oldname, ok := s.resolve(oldpath); ok {
+		w, err := writable(fsys, "rename", oldpath)
+		if err != nil {
+			return err
+		}
+		_, newname, _ := s.resolve(newpath)
+		return w.Rename(oldname, newname)
+	}
+	return os.Rename(oldpath, newpath)
+}
+
+// removeFile removes a file from the filesystem its path is on
+func (s *Store) removeFile(path string) error {
+	if fsys, name, ok := s.resolve(path); ok {
+		w, err := writable(fsys, "remove", path)
+		if err != nil {
+			return err
+		}
+		return w.Remove(name)
+	}
+	return os.Remove(path)
+}
+
+// secureRemoveFile overwrites a file before removing it on the OS filesystem
+// Other filesystems don't keep old blocks around, so there the file is just removed
+// A missing file is not an error
+func (s *Store) secureRemoveFile(path string) error {
+	if _, _, ok := s.resolve(path); ok {
+		if err := s.removeFile(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	return vault.SecureRemove(path)
+}
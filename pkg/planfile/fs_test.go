@@ -0,0 +1,152 @@
+package planfile
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// fsTestStore returns a Store for fsys at a directory that doesn't exist on disk
+func fsTestStore(t *testing.T, fsys fs.FS) *Store {
+	return &Store{Dir: filepath.Join(t.TempDir(), "plans"), FS: fsys}
+}
+
+func TestMemFS(t *testing.T) {
+	m := NewMemFS()
+
+	if err := m.WriteFile("plans/2026-02.plan", []byte("x"), 0644); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("WriteFile() without its directory error = %v, want ErrNotExist", err)
+	}
+	if err := m.MkdirAll("plans/archive", 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := m.WriteFile("plans/2026-02.plan", []byte("# 2026-02\n"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := m.Rename("plans/2026-02.plan", "plans/archive/2026-02.plan"); err != nil {
+		t.Fatalf("Rename() error = %v", err)
+	}
+
+	data, err := fs.ReadFile(m, "plans/archive/2026-02.plan")
+	if err != nil || string(data) != "# 2026-02\n" {
+		t.Errorf("ReadFile() = %q, %v", data, err)
+	}
+	entries, err := fs.ReadDir(m, "plans")
+	if err != nil || len(entries) != 1 || entries[0].Name() != "archive" || !entries[0].IsDir() {
+		t.Errorf("ReadDir() = %v, %v, want only the archive directory", entries, err)
+	}
+
+	if err := m.Remove("plans/archive"); err == nil {
+		t.Error("Remove() of a non-empty directory should fail")
+	}
+	if err := m.Remove("plans/archive/2026-02.plan"); err != nil {
+		t.Errorf("Remove() error = %v", err)
+	}
+	if _, err := fs.Stat(m, "plans/archive/2026-02.plan"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat() after Remove() error = %v, want ErrNotExist", err)
+	}
+}
+
+func TestStoreFS(t *testing.T) {
+	m := NewMemFS()
+	store := fsTestStore(t, m)
+	date := time.Date(2026, 2, 13, 0, 0, 0, 0, time.Local)
+
+	// Creating a month writes to the filesystem, not the disk
+	if _, err := store.EnsureMonthFile(date, "Goals"); err != nil {
+		t.Fatalf("EnsureMonthFile() error = %v", err)
+	}
+	if _, err := store.EnsureDateHeader(date); err != nil {
+		t.Fatalf("EnsureDateHeader() error = %v", err)
+	}
+	if _, err := os.Stat(store.Dir); !os.IsNotExist(err) {
+		t.Errorf("plans directory was created on disk, Stat() error = %v", err)
+	}
+	data, err := fs.ReadFile(m, "2026-02.plan")
+	if err != nil || string(data) != "# 2026-02\n\nGoals\n\n\n## 2026-02-13\n" {
+		t.Errorf("month file = %q, %v", data, err)
+	}
+
+	days, err := store.DiscoverDays("")
+	if err != nil || len(days) != 1 || days[0].Date != "2026-02-13" {
+		t.Errorf("DiscoverDays() = %+v, %v", days, err)
+	}
+	if _, err := fs.Stat(m, IndexFileName); err != nil {
+		t.Errorf("index should be saved in the filesystem, Stat() error = %v", err)
+	}
+
+	months, err := store.DiscoverMonths()
+	if err != nil || !reflect.DeepEqual(months, []string{"2026-02"}) {
+		t.Errorf("DiscoverMonths() = %v, %v", months, err)
+	}
+}
+
+func TestStoresOnTheSameDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "plans")
+	first := &Store{Dir: dir, FS: NewMemFS()}
+	second := &Store{Dir: dir, FS: NewMemFS()}
+
+	if _, err := first.AppendLines(time.Date(2026, 2, 13, 0, 0, 0, 0, time.Local), "", []string{"* First"}); err != nil {
+		t.Fatalf("AppendLines() error = %v", err)
+	}
+	if months, err := second.DiscoverMonths(); err != nil || len(months) != 0 {
+		t.Errorf("DiscoverMonths() on another filesystem = %v, %v, want none", months, err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("plans directory was created on disk, Stat() error = %v", err)
+	}
+}
+
+func TestStoreReadOnlyFS(t *testing.T) {
+	store := fsTestStore(t, fstest.MapFS{
+		"2026-02.plan": &fstest.MapFile{Data: []byte("# 2026-02\n\n## 2026-02-14\n* Later\n\n## 2026-02-13\n* Earlier\n")},
+	})
+
+	dates, err := store.DiscoverDates("")
+	if err != nil || !reflect.DeepEqual(dates["2026-02"], []string{"2026-02-13", "2026-02-14"}) {
+		t.Errorf("DiscoverDates() = %v, %v", dates, err)
+	}
+	content, err := store.ReadEntries("2026-02-13")
+	if err != nil || content != "## 2026-02-13\n* Earlier" {
+		t.Errorf("ReadEntries() = %q, %v", content, err)
+	}
+
+	if _, err := store.FormatPlanFile("2026-02", ""); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("FormatPlanFile() error = %v, want ErrReadOnly", err)
+	}
+	if _, err := store.AppendLines(time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local), "", []string{"* New"}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("AppendLines() error = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestStoreFSEncrypted(t *testing.T) {
+	useFastKeys(t)
+	m := NewMemFS()
+	store := fsTestStore(t, m)
+	if err := m.WriteFile("2026-02.plan", []byte(scopedTestContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.EncryptDirectory("secret"); err != nil {
+		t.Fatalf("EncryptDirectory() error = %v", err)
+	}
+	data, err := fs.ReadFile(m, "2026-02.plan.enc")
+	if err != nil || strings.Contains(string(data), "2026-02-13") {
+		t.Errorf("encrypted file = %v, should not contain plaintext", err)
+	}
+	if _, err := fs.Stat(m, "2026-02.plan"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("plain file should be removed, Stat() error = %v", err)
+	}
+
+	store = &Store{Dir: store.Dir, FS: m, Passphrase: func() (string, error) { return "secret", nil }}
+	content, err := store.ReadEntries("2026-02-13")
+	if err != nil || !strings.HasPrefix(content, "## 2026-02-13") {
+		t.Errorf("ReadEntries() = %q, %v", content, err)
+	}
+}
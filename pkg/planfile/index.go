@@ -0,0 +1,214 @@
+package planfile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+	"github.com/abyss/plan-journal-cli/pkg/vault"
+)
+
+// IndexFileName is the on-disk index of day summaries kept in the plans directory
+// It is a cache: deleting it only makes the next command re-read every month file
+const IndexFileName = ".plan-index"
+
+// indexVersion changes whenever DaySummary changes, so old indexes are rebuilt
+const indexVersion = 1
+
+// indexRacyWindow is how recently a file may have changed for its entry to be trusted
+// A file written again within the filesystem's timestamp resolution could keep its
+// mtime and size, so entries that new are re-read next time
+const indexRacyWindow = 2 * time.Second
+
+// index holds the day summaries of each month file with the mtime and size they were read at
+type index struct {
+	Version int                   `json:"version"`
+	Files   map[string]indexEntry `json:"files"` // By plaintext file name (YYYY-MM.plan)
+}
+
+type indexEntry struct {
+	ModTime int64        `json:"mtime"` // Unix nanoseconds, 0 if the entry must be re-read
+	Size    int64        `json:"size"`
+	Days    []DaySummary `json:"days"` // In file order
+}
+
+// indexedDays returns the day summaries of the month files whose month starts with filter
+// Unchanged files come from the index; changed and new files are parsed and the index is
+// updated. Files that can't be parsed are skipped
+// With headersOnly, changed files are only scanned for their date headers: the days
+// have just a date and title, and aren't added to the index
+func (s *Store) indexedDays(filter string, headersOnly bool) ([]DaySummary, error) {
+	ix, err := s.loadIndex()
+	if err != nil {
+		return nil, err
+	}
+	days, changed, err := s.refreshIndex(filter, ix, false, headersOnly)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		// The index is only a cache, so a read-only plans directory still works
+		_ = s.saveIndex(ix)
+	}
+	return days, nil
+}
+
+// Reindex rebuilds the index of the plans directory from every month file
+// Returns the number of files and days indexed
+func (s *Store) Reindex() (int, int, error) {
+	ix := &index{Version: indexVersion, Files: map[string]indexEntry{}}
+	days, _, err := s.refreshIndex("", ix, true, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := s.saveIndex(ix); err != nil {
+		return 0, 0, err
+	}
+	return len(ix.Files), len(days), nil
+}
+
+// refreshIndex brings the index entries for the month files matching filter up to date
+// Entries for files that no longer exist are dropped. With force, every file is re-read
+// Changed files are parsed in parallel (see parseFiles). Reports whether the index changed
+func (s *Store) refreshIndex(filter string, ix *index, force, headersOnly bool) ([]DaySummary, bool, error) {
+	fileNames, err := s.planFileNames()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	// Use the index for unchanged files and collect the rest for parsing
+	type monthFile struct {
+		name string
+		info os.FileInfo
+		days []DaySummary
+	}
+	var files []*monthFile
+	var stale []*monthFile
+	seen := make(map[string]bool, len(fileNames))
+	for _, fileName := range fileNames {
+		month := strings.TrimSuffix(fileName, ".plan")
+		if !dateutil.IsValidMonth(month) {
+			continue
+		}
+		seen[fileName] = true
+		// Skip whole months outside the filter before parsing
+		if filter != "" && !strings.HasPrefix(month, filter) {
+			continue
+		}
+
+		info, err := s.statPlan(filepath.Join(s.Dir, fileName))
+		if err != nil {
+			continue
+		}
+		file := &monthFile{name: fileName, info: info}
+		files = append(files, file)
+
+		entry, ok := ix.Files[fileName]
+		if !force && ok && entry.ModTime != 0 && entry.ModTime == info.ModTime().UnixNano() && entry.Size == info.Size() {
+			file.days = entry.Days
+		} else {
+			stale = append(stale, file)
+		}
+	}
+
+	paths := make([]string, len(stale))
+	for i, file := range stale {
+		paths[i] = filepath.Join(s.Dir, file.name)
+	}
+	results, err := s.parseFiles(paths, headersOnly)
+	if err != nil {
+		return nil, false, err
+	}
+
+	changed := false
+	for i, file := range stale {
+		pf := results[i]
+		if pf == nil {
+			if _, ok := ix.Files[file.name]; ok {
+				delete(ix.Files, file.name)
+				changed = true
+			}
+			continue
+		}
+
+		file.days = make([]DaySummary, 0, len(pf.DateOrder))
+		for _, date := range pf.DateOrder {
+			if headersOnly {
+				file.days = append(file.days, DaySummary{Date: date, Title: HeaderTitle(date, pf.DateHeaders[date])})
+			} else {
+				file.days = append(file.days, SummarizeDay(date, pf.DateHeaders[date], pf.Dates[date]))
+			}
+		}
+		if headersOnly {
+			continue
+		}
+
+		entry := indexEntry{ModTime: file.info.ModTime().UnixNano(), Size: file.info.Size(), Days: file.days}
+		if time.Since(file.info.ModTime()) < indexRacyWindow {
+			entry.ModTime = 0
+		}
+		ix.Files[file.name] = entry
+		changed = true
+	}
+
+	var days []DaySummary
+	for _, file := range files {
+		days = append(days, file.days...)
+	}
+
+	for fileName := range ix.Files {
+		if !seen[fileName] {
+			delete(ix.Files, fileName)
+			changed = true
+		}
+	}
+	return days, changed, nil
+}
+
+// loadIndex reads the index of the plans directory
+// A missing, unreadable, or outdated index is returned empty so it gets rebuilt
+func (s *Store) loadIndex() (*index, error) {
+	empty := &index{Version: indexVersion, Files: map[string]indexEntry{}}
+
+	data, err := s.readPlan(filepath.Join(s.Dir, IndexFileName))
+	if IsUnlockError(err) {
+		return nil, err
+	}
+	if err != nil {
+		return empty, nil
+	}
+
+	var ix index
+	if err := json.Unmarshal(data, &ix); err != nil || ix.Version != indexVersion || ix.Files == nil {
+		return empty, nil
+	}
+	return &ix, nil
+}
+
+// saveIndex writes the index, encrypted like the plan files in an encrypted journal
+func (s *Store) saveIndex(ix *index) error {
+	data, err := json.Marshal(ix)
+	if err != nil {
+		return err
+	}
+	return s.writePlan(filepath.Join(s.Dir, IndexFileName), data, 0644)
+}
+
+// removeIndex deletes the index in both its plain and encrypted form
+// The plain one may list titles and tags, so it is overwritten first
+func (s *Store) removeIndex() error {
+	path := filepath.Join(s.Dir, IndexFileName)
+	if err := s.secureRemoveFile(path); err != nil {
+		return err
+	}
+	if err := s.removeFile(path + vault.Extension); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package planfile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/vault"
+)
+
+// writeAged writes a plan file with an mtime in the past, outside the racy window
+func writeAged(t *testing.T, path, content string, age time.Duration) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	mtime := time.Now().Add(-age)
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatalf("Failed to set mtime: %v", err)
+	}
+}
+
+// tamperIndex rewrites the title of every indexed day, to tell cached days from parsed ones
+func tamperIndex(t *testing.T, plansDir string) {
+	t.Helper()
+	path := filepath.Join(plansDir, IndexFileName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("index not written: %v", err)
+	}
+	var ix index
+	if err := json.Unmarshal(data, &ix); err != nil {
+		t.Fatalf("index is not valid JSON: %v", err)
+	}
+	for name, entry := range ix.Files {
+		for i := range entry.Days {
+			entry.Days[i].Title = "cached"
+		}
+		ix.Files[name] = entry
+	}
+	data, _ = json.Marshal(ix)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIndexReusesUnchangedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
+	jan := filepath.Join(tmpDir, "2026-01.plan")
+	feb := filepath.Join(tmpDir, "2026-02.plan")
+	writeAged(t, jan, "# 2026-01\n\n## 2026-01-05 - First\n* One #work\n", time.Hour)
+	writeAged(t, feb, "# 2026-02\n\n## 2026-02-02 - Second\n* Two\n", time.Hour)
+
+	if _, err := store.DiscoverDays(""); err != nil {
+		t.Fatalf("DiscoverDays() error = %v", err)
+	}
+	tamperIndex(t, tmpDir)
+
+	// Unchanged files come from the index
+	days, err := store.DiscoverDays("")
+	if err != nil || len(days) != 2 || days[0].Title != "cached" || days[1].Title != "cached" {
+		t.Fatalf("DiscoverDays() = %+v, %v, want both days from the index", days, err)
+	}
+
+	// A changed file is parsed again; the other one still comes from the index
+	writeAged(t, feb, "# 2026-02\n\n## 2026-02-02 - Second\n* Two\n\n## 2026-02-03\n* Three\n", 30*time.Minute)
+	days, err = store.DiscoverDays("")
+	if err != nil || len(days) != 3 || days[0].Title != "cached" || days[1].Title != "Second" {
+		t.Fatalf("DiscoverDays() after change = %+v, %v", days, err)
+	}
+
+	// Deleted files are dropped from the index
+	if err := os.Remove(jan); err != nil {
+		t.Fatal(err)
+	}
+	dates, err := store.DiscoverDates("")
+	if err != nil || len(dates) != 1 || len(dates["2026-02"]) != 2 {
+		t.Errorf("DiscoverDates() after delete = %v, %v", dates, err)
+	}
+	ix, _ := store.loadIndex()
+	if _, ok := ix.Files["2026-01.plan"]; ok || len(ix.Files) != 1 {
+		t.Errorf("index files = %v, want only 2026-02.plan", ix.Files)
+	}
+}
+
+func TestIndexSkipsRecentFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
+	if err := os.WriteFile(filepath.Join(tmpDir, "2026-02.plan"), []byte("# 2026-02\n\n## 2026-02-02 - Fresh\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.DiscoverDays(""); err != nil {
+		t.Fatalf("DiscoverDays() error = %v", err)
+	}
+	tamperIndex(t, tmpDir)
+
+	// A file changed just now could change again without its mtime moving
+	days, err := store.DiscoverDays("")
+	if err != nil || len(days) != 1 || days[0].Title != "Fresh" {
+		t.Errorf("DiscoverDays() = %+v, %v, want the recent file parsed again", days, err)
+	}
+}
+
+func TestReindex(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
+	writeAged(t, filepath.Join(tmpDir, "2026-01.plan"), "# 2026-01\n\n## 2026-01-05 - First\n\n## 2026-01-06\n", time.Hour)
+	writeAged(t, filepath.Join(tmpDir, "2026-02.plan"), "# 2026-02\n\n## 2026-02-02 - Second\n", time.Hour)
+
+	if _, err := store.DiscoverDays(""); err != nil {
+		t.Fatal(err)
+	}
+	tamperIndex(t, tmpDir)
+
+	files, days, err := store.Reindex()
+	if err != nil || files != 2 || days != 3 {
+		t.Fatalf("Reindex() = %d, %d, %v, want 2 files and 3 days", files, days, err)
+	}
+	summaries, _ := store.DiscoverDays("")
+	if summaries[0].Title != "First" {
+		t.Errorf("DiscoverDays() after Reindex() title = %q, want First", summaries[0].Title)
+	}
+
+	// A corrupt index is ignored and rebuilt
+	if err := os.WriteFile(filepath.Join(tmpDir, IndexFileName), []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if summaries, err := store.DiscoverDays(""); err != nil || len(summaries) != 3 {
+		t.Errorf("DiscoverDays() with corrupt index = %v, %v", summaries, err)
+	}
+}
+
+func TestIndexEncrypted(t *testing.T) {
+	store := encryptedTestStore(t, "secret")
+	tmpDir := store.Dir
+	store = &Store{Dir: tmpDir, Passphrase: func() (string, error) { return "secret", nil }}
+
+	if _, _, err := store.Reindex(); err != nil {
+		t.Fatalf("Reindex() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, IndexFileName)); !os.IsNotExist(err) {
+		t.Error("plain index written in an encrypted journal")
+	}
+	data, err := os.ReadFile(filepath.Join(tmpDir, IndexFileName+vault.Extension))
+	if err != nil || !vault.IsEncrypted(data) || strings.Contains(string(data), "2026-02-13") {
+		t.Errorf("index is not stored encrypted: %v", err)
+	}
+
+	// Decrypting removes the encrypted index; it is rebuilt plain on next use
+	if _, err := store.DecryptDirectory("secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, IndexFileName+vault.Extension)); !os.IsNotExist(err) {
+		t.Error("DecryptDirectory() left the encrypted index")
+	}
+}
@@ -0,0 +1,74 @@
+package planfile
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// LockFileName is the file in the plans directory that writers lock, so the CLI and
+// plan serve (or two CLI commands) never change a month file at the same time
+const LockFileName = ".plan-lock"
+
+// dirLocks serializes writers to each plans directory within this process
+var (
+	dirLocks   = map[string]*sync.Mutex{}
+	dirLocksMu sync.Mutex
+)
+
+// lockDirectory waits for and takes the write lock of a plans directory, returning the
+// function that releases it. Every read-modify-write of a plan file happens under it
+// Other processes are locked out through LockFileName on the OS filesystem; another
+// filesystem or a directory that doesn't exist yet is only locked within this process
+func (s *Store) lockDirectory(plansDir string) (func(), error) {
+	dir, err := filepath.Abs(plansDir)
+	if err != nil {
+		return nil, err
+	}
+
+	dirLocksMu.Lock()
+	mu, ok := dirLocks[dir]
+	if !ok {
+		mu = &sync.Mutex{}
+		dirLocks[dir] = mu
+	}
+	dirLocksMu.Unlock()
+
+	mu.Lock()
+	if _, _, ok := s.resolve(dir); ok {
+		return mu.Unlock, nil
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, LockFileName), os.O_RDWR|os.O_CREATE, 0600)
+	if errors.Is(err, fs.ErrNotExist) {
+		return mu.Unlock, nil
+	}
+	if err != nil {
+		mu.Unlock()
+		return nil, fmt.Errorf("failed to lock plans directory: %w", err)
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		mu.Unlock()
+		return nil, fmt.Errorf("failed to lock plans directory: %w", err)
+	}
+
+	return func() {
+		unlockFile(f)
+		f.Close()
+		mu.Unlock()
+	}, nil
+}
+
+// withLock runs fn while holding the write lock of a plans directory
+func (s *Store) withLock(plansDir string, fn func() error) error {
+	unlock, err := s.lockDirectory(plansDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return fn()
+}
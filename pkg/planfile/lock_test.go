@@ -0,0 +1,113 @@
+package planfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrentAppends(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
+	date := time.Date(2026, 2, 13, 0, 0, 0, 0, time.Local)
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Go(func() {
+			if _, err := store.AppendLines(date, "", []string{fmt.Sprintf("* Entry %d", i)}); err != nil {
+				t.Errorf("AppendLines() error = %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	content, err := store.ReadEntries("2026-02-13")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 20 {
+		if !strings.Contains(content+"\n", fmt.Sprintf("* Entry %d\n", i)) {
+			t.Errorf("entry %d was lost:\n%s", i, content)
+		}
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if name := entry.Name(); name != "2026-02.plan" && name != LockFileName && name != IndexFileName {
+			t.Errorf("unexpected file left in plans directory: %s", name)
+		}
+	}
+}
+
+func TestLockDirectoryExcludesOtherProcesses(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
+	unlock, err := store.lockDirectory(tmpDir)
+	if err != nil {
+		t.Fatalf("lockDirectory() error = %v", err)
+	}
+
+	// A separate open of the lock file stands in for another process
+	f, err := os.OpenFile(filepath.Join(tmpDir, LockFileName), os.O_RDWR, 0)
+	if err != nil {
+		t.Fatalf("lock file not created: %v", err)
+	}
+	defer f.Close()
+	locked := make(chan error, 1)
+	go func() { locked <- lockFile(f) }()
+
+	select {
+	case <-locked:
+		t.Fatal("lock was taken while the directory was locked")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case err := <-locked:
+		if err != nil {
+			t.Fatalf("lockFile() error = %v", err)
+		}
+		unlockFile(f)
+	case <-time.After(5 * time.Second):
+		t.Fatal("lock was not released")
+	}
+}
+
+func TestReplaceFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
+	target := filepath.Join(tmpDir, "target.plan")
+	if err := os.WriteFile(target, []byte("old\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(tmpDir, "2026-02.plan")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	if err := store.writePlan(link, []byte("new\n"), 0644); err != nil {
+		t.Fatalf("writePlan() error = %v", err)
+	}
+
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("symlink was replaced, Lstat() = %v, %v", info, err)
+	}
+	data, err := os.ReadFile(target)
+	if err != nil || string(data) != "new\n" {
+		t.Errorf("target = %q, %v", data, err)
+	}
+	if info, err := os.Stat(target); err == nil && info.Mode().Perm() != 0600 {
+		t.Errorf("permissions = %v, want the existing 0600", info.Mode().Perm())
+	}
+	if entries, _ := os.ReadDir(tmpDir); len(entries) != 2 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+}
@@ -4,44 +4,63 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+	"github.com/abyss/plan-journal-cli/pkg/vault"
 )
 
 // EnsureMonthFile ensures a month file exists with header and preamble
-func EnsureMonthFile(date time.Time, plansDir, preamble string) error {
+// Reports whether the file was created
+func (s *Store) EnsureMonthFile(date time.Time, preamble string) (bool, error) {
 	// Ensure directory exists
-	if err := EnsureDirectory(plansDir); err != nil {
-		return fmt.Errorf("failed to create plans directory: %w", err)
+	if err := s.EnsureDirectory(); err != nil {
+		return false, fmt.Errorf("failed to create plans directory: %w", err)
 	}
 
+	var created bool
+	err := s.withLock(s.Dir, func() (err error) {
+		created, err = s.ensureMonthFile(date, preamble)
+		return err
+	})
+	return created, err
+}
+
+// ensureMonthFile is EnsureMonthFile under the directory lock; reports whether the file was created
+func (s *Store) ensureMonthFile(date time.Time, preamble string) (bool, error) {
 	// Build file path
 	fileName := dateutil.MonthFileName(date)
-	filePath := filepath.Join(plansDir, fileName)
+	filePath := filepath.Join(s.Dir, fileName)
 
 	// Check if file exists
-	if _, err := os.Stat(filePath); err == nil {
+	if _, err := s.statPlan(filePath); err == nil {
 		// File exists, ensure it has a preamble
-		return EnsurePreamble(filePath, preamble)
+		return false, s.ensurePreamble(filePath, preamble)
 	}
 
 	// Create new file with month header and preamble
 	monthHeader := dateutil.MonthHeader(date)
 	content := monthHeader + "\n\n" + preamble + "\n"
 
-	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
-		return fmt.Errorf("failed to create month file: %w", err)
+	if err := s.writePlan(filePath, []byte(content), 0644); err != nil {
+		return false, fmt.Errorf("failed to create month file: %w", err)
 	}
-
-	return nil
+	return true, nil
 }
 
 // EnsurePreamble ensures a file has the correct preamble
-func EnsurePreamble(filePath, preamble string) error {
-	pf, err := ParseFile(filePath)
+func (s *Store) EnsurePreamble(filePath, preamble string) error {
+	return s.withLock(filepath.Dir(filePath), func() error {
+		return s.ensurePreamble(filePath, preamble)
+	})
+}
+
+// ensurePreamble is EnsurePreamble under the directory lock
+func (s *Store) ensurePreamble(filePath, preamble string) error {
+	pf, err := s.ParseFile(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to parse file: %w", err)
 	}
@@ -53,28 +72,38 @@ func EnsurePreamble(filePath, preamble string) error {
 
 	// Update preamble
 	pf.Preamble = preamble
-	return WritePlanFile(filePath, pf)
+	return s.WritePlanFile(filePath, pf)
 }
 
 // EnsureDateHeader ensures a date header exists in the file
-// Inserts it in chronological order if it doesn't exist
-func EnsureDateHeader(date time.Time, plansDir string) error {
+// Inserts it in chronological order if it doesn't exist, and reports whether it was added
+func (s *Store) EnsureDateHeader(date time.Time) (bool, error) {
+	var created bool
+	err := s.withLock(s.Dir, func() (err error) {
+		created, err = s.ensureDateHeader(date)
+		return err
+	})
+	return created, err
+}
+
+// ensureDateHeader is EnsureDateHeader under the directory lock; reports whether the header was added
+func (s *Store) ensureDateHeader(date time.Time) (bool, error) {
 	// Ensure month file exists first
-	filePath := filepath.Join(plansDir, dateutil.MonthFileName(date))
-	if _, err := os.Stat(filePath); err != nil {
-		return fmt.Errorf("failed to access month file: %w", err)
+	filePath := filepath.Join(s.Dir, dateutil.MonthFileName(date))
+	if _, err := s.statPlan(filePath); err != nil {
+		return false, fmt.Errorf("failed to access month file: %w", err)
 	}
 
 	// Parse file
-	pf, err := ParseFile(filePath)
+	pf, err := s.ParseFile(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to parse file: %w", err)
+		return false, fmt.Errorf("failed to parse file: %w", err)
 	}
 
 	// Check if date already exists
 	dateStr := dateutil.FormatDate(date)
 	if _, exists := pf.Dates[dateStr]; exists {
-		return nil
+		return false, nil
 	}
 
 	// Add new date section
@@ -82,15 +111,18 @@ func EnsureDateHeader(date time.Time, plansDir string) error {
 	pf.DateOrder = append(pf.DateOrder, dateStr)
 
 	// Write updated file (will be sorted chronologically)
-	return WritePlanFile(filePath, pf)
+	if err := s.WritePlanFile(filePath, pf); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // FindInsertionPoint returns the file path and line number for inserting new entries
-func FindInsertionPoint(date time.Time, plansDir string) (string, int, error) {
-	filePath := filepath.Join(plansDir, dateutil.MonthFileName(date))
+func (s *Store) FindInsertionPoint(date time.Time) (string, int, error) {
+	filePath := filepath.Join(s.Dir, dateutil.MonthFileName(date))
 	dateStr := dateutil.FormatDate(date)
 
-	lineNum, err := FindInsertionLineForDate(filePath, dateStr)
+	lineNum, err := s.FindInsertionLineForDate(filePath, dateStr)
 	if err != nil {
 		return "", 0, fmt.Errorf("failed to find insertion point: %w", err)
 	}
@@ -100,23 +132,23 @@ func FindInsertionPoint(date time.Time, plansDir string) (string, int, error) {
 
 // ReadEntries reads and returns entries based on the target
 // target can be "yesterday", "today", "tomorrow", "YYYY-MM", or "YYYY-MM-DD"
-func ReadEntries(target, plansDir string) (string, error) {
+func (s *Store) ReadEntries(target string) (string, error) {
 	// Parse target
 	date, err := dateutil.ParseTarget(target)
 	if err != nil {
 		return "", err
 	}
 
-	filePath := filepath.Join(plansDir, dateutil.MonthFileName(date))
+	filePath := filepath.Join(s.Dir, dateutil.MonthFileName(date))
 
 	// Check if file exists
-	if _, err := os.Stat(filePath); err != nil {
+	if _, err := s.statPlan(filePath); err != nil {
 		return "", fmt.Errorf("no plan file found for %s", target)
 	}
 
 	// If target is a month format (YYYY-MM), return entire file
 	if dateutil.IsValidMonth(target) {
-		content, err := os.ReadFile(filePath)
+		content, err := s.readPlan(filePath)
 		if err != nil {
 			return "", fmt.Errorf("failed to read file: %w", err)
 		}
@@ -125,7 +157,7 @@ func ReadEntries(target, plansDir string) (string, error) {
 
 	// For specific date, extract the date section
 	dateStr := dateutil.FormatDate(date)
-	content, err := ExtractDateContent(filePath, dateStr)
+	content, err := s.ExtractDateContent(filePath, dateStr)
 	if err != nil {
 		return "", fmt.Errorf("failed to extract date content: %w", err)
 	}
@@ -138,19 +170,27 @@ func ReadEntries(target, plansDir string) (string, error) {
 	return content, nil
 }
 
+// ResolveTargetFile resolves a format target (date, file path, or filename) to an existing plan file path
+func (s *Store) ResolveTargetFile(target string) (string, error) {
+	return s.resolveTargetToFilePath(target)
+}
+
 // resolveTargetToFilePath resolves a target (date string or file path) to an absolute file path
 // target can be:
 // - A date string (YYYY-MM, YYYY-MM-DD, today, yesterday, tomorrow)
 // - An absolute file path
 // - A relative file path
-// - A filename (looked up in plansDir)
-func resolveTargetToFilePath(target, plansDir string) (string, error) {
+// - A filename (looked up in the plans directory)
+func (s *Store) resolveTargetToFilePath(target string) (string, error) {
+	// Encrypted files are named by their plaintext name
+	target = strings.TrimSuffix(target, vault.Extension)
+
 	// First, try to parse as a date
 	date, err := dateutil.ParseTarget(target)
 	if err == nil {
 		// Valid date, construct file path
-		filePath := filepath.Join(plansDir, dateutil.MonthFileName(date))
-		if _, statErr := os.Stat(filePath); statErr != nil {
+		filePath := filepath.Join(s.Dir, dateutil.MonthFileName(date))
+		if _, statErr := s.statPlan(filePath); statErr != nil {
 			return "", fmt.Errorf("no plan file found for %s", target)
 		}
 		return filePath, nil
@@ -164,7 +204,7 @@ func resolveTargetToFilePath(target, plansDir string) (string, error) {
 		filePath = target
 	} else {
 		// Check if the relative path exists from current directory
-		if _, statErr := os.Stat(target); statErr == nil {
+		if _, statErr := s.statPlan(target); statErr == nil {
 			absPath, absErr := filepath.Abs(target)
 			if absErr == nil {
 				filePath = absPath
@@ -173,21 +213,21 @@ func resolveTargetToFilePath(target, plansDir string) (string, error) {
 			}
 		} else {
 			// Try as a filename in the plans directory
-			filePath = filepath.Join(plansDir, target)
+			filePath = filepath.Join(s.Dir, target)
 		}
 	}
 
 	// Verify the file exists
-	if _, statErr := os.Stat(filePath); statErr != nil {
+	if _, statErr := s.statPlan(filePath); statErr != nil {
 		return "", fmt.Errorf("file not found: %s (tried as date, absolute path, relative path, and filename in plans directory)", target)
 	}
 
 	return filePath, nil
 }
 
-// DiscoverDates scans all plan files and returns dates grouped by month
+// DiscoverDates returns the dates in all plan files grouped by month
 // filter can be empty (all dates), YYYY (specific year), or YYYY-MM (specific month)
-func DiscoverDates(plansDir, filter string) (map[string][]string, error) {
+func (s *Store) DiscoverDates(filter string) (map[string][]string, error) {
 	// Validate filter if provided
 	if filter != "" {
 		// Check if it's a valid year (YYYY)
@@ -203,11 +243,12 @@ func DiscoverDates(plansDir, filter string) (map[string][]string, error) {
 		}
 	}
 
-	// Read all .plan files
-	entries, err := os.ReadDir(plansDir)
+	// Read the dates of the matching .plan files (unchanged files come from the index,
+	// and only the date headers of changed files are read)
+	days, err := s.indexedDays(filter, true)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return make(map[string][]string), nil
+		if IsUnlockError(err) {
+			return nil, err
 		}
 		return nil, fmt.Errorf("failed to read plans directory: %w", err)
 	}
@@ -215,38 +256,15 @@ func DiscoverDates(plansDir, filter string) (map[string][]string, error) {
 	// Collect all dates
 	allDates := make(map[string][]string) // month -> []dates
 
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".plan") {
-			continue
-		}
-
-		// Only process files matching YYYY-MM.plan pattern
-		// Strip .plan suffix and check if it's a valid month format
-		fileName := entry.Name()
-		monthPart := strings.TrimSuffix(fileName, ".plan")
-		if !dateutil.IsValidMonth(monthPart) {
-			// Skip files that don't match YYYY-MM.plan pattern
-			continue
-		}
-
-		filePath := filepath.Join(plansDir, fileName)
-		pf, err := ParseFile(filePath)
-		if err != nil {
-			// Skip files that can't be parsed
+	for _, day := range days {
+		// Apply filter (works for both YYYY and YYYY-MM formats)
+		if filter != "" && !strings.HasPrefix(day.Date, filter+"-") {
 			continue
 		}
 
-		// Process each date in the file
-		for _, date := range pf.DateOrder {
-			// Apply filter (works for both YYYY and YYYY-MM formats)
-			if filter != "" && !strings.HasPrefix(date, filter+"-") {
-				continue
-			}
-
-			// Extract month (YYYY-MM)
-			month := date[:7] // First 7 characters: YYYY-MM
-			allDates[month] = append(allDates[month], date)
-		}
+		// Extract month (YYYY-MM)
+		month := day.Date[:7] // First 7 characters: YYYY-MM
+		allDates[month] = append(allDates[month], day.Date)
 	}
 
 	// Sort dates within each month
@@ -261,23 +279,52 @@ func DiscoverDates(plansDir, filter string) (map[string][]string, error) {
 
 // FormatPlanFile formats a plan file by reordering dates and updating preamble
 // target can be a date string (YYYY-MM, YYYY-MM-DD, today, etc.) or a file path
-func FormatPlanFile(target, plansDir, preamble string) (string, error) {
+func (s *Store) FormatPlanFile(target, preamble string) (string, error) {
 	// Resolve target to file path
-	filePath, err := resolveTargetToFilePath(target, plansDir)
+	filePath, err := s.resolveTargetToFilePath(target)
 	if err != nil {
 		return "", err
 	}
 
+	changes, err := s.FormatFile(filePath, preamble)
+	if err != nil {
+		return "", err
+	}
+
+	if len(changes) == 0 {
+		return "No changes needed", nil
+	}
+
+	return "Changes: " + strings.Join(changes, ", "), nil
+}
+
+// FormatFile reorders the date sections of a plan file, updates its preamble, and
+// normalizes spacing. Returns what was changed, or nothing if the file was already
+// formatted and left untouched
+func (s *Store) FormatFile(filePath, preamble string) ([]string, error) {
+	var changes []string
+	err := s.withLock(filepath.Dir(filePath), func() (err error) {
+		changes, err = s.formatFile(filePath, preamble)
+		return err
+	})
+	if err != nil || len(changes) == 0 {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// formatFile is FormatFile under the directory lock
+func (s *Store) formatFile(filePath, preamble string) ([]string, error) {
 	// Read original file content
-	originalContent, err := os.ReadFile(filePath)
+	originalContent, err := s.readPlan(filePath)
 	if err != nil {
-		return "", fmt.Errorf("failed to read file: %w", err)
+		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
 	// Parse file
-	pf, err := ParseFile(filePath)
+	pf, err := s.ParseFile(filePath)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse file: %w", err)
+		return nil, fmt.Errorf("failed to parse file: %w", err)
 	}
 
 	// Track what was changed
@@ -320,14 +367,124 @@ func FormatPlanFile(target, plansDir, preamble string) (string, error) {
 
 	// Only write if there are changes
 	if string(originalContent) != formattedContent {
-		if err := os.WriteFile(filePath, []byte(formattedContent), 0644); err != nil {
-			return "", fmt.Errorf("failed to write formatted file: %w", err)
+		if err := s.writePlan(filePath, []byte(formattedContent), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write formatted file: %w", err)
 		}
 	}
 
-	if len(changes) == 0 {
-		return "No changes needed", nil
+	return changes, nil
+}
+
+// Created reports what AppendLines added before appending
+type Created struct {
+	Month bool // The month file was created
+	Day   bool // The date header was added
+}
+
+// AppendLines adds lines to the end of a date section, creating the month file and
+// date header first if needed (like edit does)
+// The rest of the file is left as written: sections aren't reordered or reformatted
+func (s *Store) AppendLines(date time.Time, preamble string, lines []string) (Created, error) {
+	var created Created
+	if err := s.EnsureDirectory(); err != nil {
+		return created, fmt.Errorf("failed to create plans directory: %w", err)
 	}
 
-	return "Changes: " + strings.Join(changes, ", "), nil
+	err := s.withLock(s.Dir, func() (err error) {
+		filePath := filepath.Join(s.Dir, dateutil.MonthFileName(date))
+		if _, err := s.statPlan(filePath); err != nil {
+			if created.Month, err = s.ensureMonthFile(date, preamble); err != nil {
+				return err
+			}
+		}
+		created.Day, err = s.appendLines(filePath, dateutil.FormatDate(date), lines)
+		return err
+	})
+	return created, err
+}
+
+// appendLines inserts lines after the last entry of a date section under the directory
+// lock, adding the date header first if it's missing. Reports whether the header was added
+func (s *Store) appendLines(filePath, dateStr string, entries []string) (bool, error) {
+	lines, err := s.readLines(filePath)
+	if err != nil {
+		return false, err
+	}
+
+	added := false
+	headerIdx, lastContentIdx := findSection(lines, dateStr)
+	if headerIdx == -1 {
+		lines = insertDateHeader(lines, dateStr)
+		headerIdx, lastContentIdx = findSection(lines, dateStr)
+		added = true
+	}
+
+	insertIdx := lastContentIdx + 1
+	lines = append(lines[:insertIdx], append(slices.Clone(entries), lines[insertIdx:]...)...)
+	return added, s.writeLines(filePath, lines)
+}
+
+// insertDateHeader adds "## dateStr" before the first later date section, or else after
+// the last date section (ahead of any non-date "##" heading that follows it)
+func insertDateHeader(lines []string, dateStr string) []string {
+	at, lastDate, afterLast := -1, -1, -1
+	for i, line := range lines {
+		text, ok := strings.CutPrefix(line, "## ")
+		if !ok {
+			continue
+		}
+		if date, ok := headerDate(text); ok {
+			if dateutil.CompareDates(date, dateStr) > 0 {
+				at = i
+				break
+			}
+			lastDate, afterLast = i, -1
+		} else if lastDate != -1 && afterLast == -1 {
+			afterLast = i
+		}
+	}
+	switch {
+	case at != -1:
+	case afterLast != -1:
+		at = afterLast
+	default:
+		at = len(lines)
+	}
+
+	// Sections are separated by two blank lines, like GenerateFileContent writes them
+	start := at
+	for start > 0 && lines[start-1] == "" {
+		start--
+	}
+	section := []string{"## " + dateStr}
+	if start > 0 {
+		if strings.HasPrefix(lines[start-1], "# ") {
+			section = append([]string{""}, section...)
+		} else {
+			section = append([]string{"", ""}, section...)
+		}
+	}
+	if at < len(lines) {
+		section = append(section, "", "")
+	}
+	return slices.Concat(lines[:start], section, lines[at:])
+}
+
+// DiscoverMonths returns the months (YYYY-MM) that have a plan file, oldest first
+func (s *Store) DiscoverMonths() ([]string, error) {
+	fileNames, err := s.planFileNames()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read plans directory: %w", err)
+	}
+
+	var months []string
+	for _, fileName := range fileNames {
+		if month := strings.TrimSuffix(fileName, ".plan"); dateutil.IsValidMonth(month) {
+			months = append(months, month)
+		}
+	}
+	return months, nil
 }
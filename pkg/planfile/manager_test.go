@@ -10,12 +10,16 @@ import (
 
 func TestEnsureMonthFile(t *testing.T) {
 	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
 	date := time.Date(2026, 2, 13, 0, 0, 0, 0, time.UTC)
 	preamble := "Test preamble"
 
-	err := EnsureMonthFile(date, tmpDir, preamble)
-	if err != nil {
-		t.Fatalf("EnsureMonthFile() error = %v", err)
+	created, err := store.EnsureMonthFile(date, preamble)
+	if err != nil || !created {
+		t.Fatalf("EnsureMonthFile() = %v, %v, want created", created, err)
+	}
+	if created, err := store.EnsureMonthFile(date, preamble); err != nil || created {
+		t.Errorf("EnsureMonthFile() again = %v, %v, want not created", created, err)
 	}
 
 	// Calculate expected file path
@@ -43,6 +47,7 @@ func TestEnsureMonthFile(t *testing.T) {
 
 func TestEnsurePreamble(t *testing.T) {
 	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
 	testFile := filepath.Join(tmpDir, "2026-02.plan")
 
 	// Create file without preamble
@@ -58,7 +63,7 @@ func TestEnsurePreamble(t *testing.T) {
 
 	// Add preamble
 	preamble := "New preamble"
-	if err := EnsurePreamble(testFile, preamble); err != nil {
+	if err := store.EnsurePreamble(testFile, preamble); err != nil {
 		t.Fatalf("EnsurePreamble() error = %v", err)
 	}
 
@@ -75,6 +80,7 @@ func TestEnsurePreamble(t *testing.T) {
 
 func TestEnsureDateHeader(t *testing.T) {
 	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
 	testFile := filepath.Join(tmpDir, "2026-02.plan")
 
 	// Create file with month header
@@ -88,9 +94,12 @@ Test preamble
 	}
 
 	date := time.Date(2026, 2, 13, 0, 0, 0, 0, time.UTC)
-	err := EnsureDateHeader(date, tmpDir)
-	if err != nil {
-		t.Fatalf("EnsureDateHeader() error = %v", err)
+	created, err := store.EnsureDateHeader(date)
+	if err != nil || !created {
+		t.Fatalf("EnsureDateHeader() = %v, %v, want created", created, err)
+	}
+	if created, err := store.EnsureDateHeader(date); err != nil || created {
+		t.Errorf("EnsureDateHeader() again = %v, %v, want not created", created, err)
 	}
 
 	// Check file now has date header
@@ -106,6 +115,7 @@ Test preamble
 
 func TestFormatPlanFile(t *testing.T) {
 	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
 	testFile := filepath.Join(tmpDir, "2026-02.plan")
 
 	// Create file with dates out of order
@@ -126,7 +136,7 @@ func TestFormatPlanFile(t *testing.T) {
 	}
 
 	preamble := "Test preamble"
-	result, err := FormatPlanFile("2026-02", tmpDir, preamble)
+	result, err := store.FormatPlanFile("2026-02", preamble)
 	if err != nil {
 		t.Fatalf("FormatPlanFile() error = %v", err)
 	}
@@ -165,6 +175,7 @@ func TestFormatPlanFile(t *testing.T) {
 
 func TestReadEntries(t *testing.T) {
 	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
 	testFile := filepath.Join(tmpDir, "2026-02.plan")
 
 	content := `# 2026-02
@@ -184,7 +195,7 @@ Test preamble
 	}
 
 	// Test reading specific date
-	result, err := ReadEntries("2026-02-13", tmpDir)
+	result, err := store.ReadEntries("2026-02-13")
 	if err != nil {
 		t.Fatalf("ReadEntries() error = %v", err)
 	}
@@ -197,7 +208,7 @@ Test preamble
 	}
 
 	// Test reading entire month
-	result, err = ReadEntries("2026-02", tmpDir)
+	result, err = store.ReadEntries("2026-02")
 	if err != nil {
 		t.Fatalf("ReadEntries() error = %v", err)
 	}
@@ -219,7 +230,7 @@ Test preamble
 		t.Fatalf("Failed to create test file: %v", err)
 	}
 
-	result, err = ReadEntries("2026-03-01", tmpDir)
+	result, err = store.ReadEntries("2026-03-01")
 	if err != nil {
 		t.Fatalf("ReadEntries() error = %v", err)
 	}
@@ -235,7 +246,7 @@ Test preamble
 	}
 
 	// Test reading date that doesn't exist in file
-	_, err = ReadEntries("2026-03-15", tmpDir)
+	_, err = store.ReadEntries("2026-03-15")
 	if err == nil {
 		t.Error("ReadEntries() for non-existent date should return error")
 	}
@@ -246,6 +257,7 @@ Test preamble
 
 func TestDiscoverDates(t *testing.T) {
 	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
 
 	// Create multiple plan files with dates across different months
 	file1 := filepath.Join(tmpDir, "2026-01.plan")
@@ -292,7 +304,7 @@ func TestDiscoverDates(t *testing.T) {
 
 	// Test discovering all dates
 	t.Run("AllDates", func(t *testing.T) {
-		result, err := DiscoverDates(tmpDir, "")
+		result, err := store.DiscoverDates("")
 		if err != nil {
 			t.Fatalf("DiscoverDates() error = %v", err)
 		}
@@ -334,7 +346,7 @@ func TestDiscoverDates(t *testing.T) {
 
 	// Test filtering by year
 	t.Run("FilterByYear", func(t *testing.T) {
-		result, err := DiscoverDates(tmpDir, "2026")
+		result, err := store.DiscoverDates("2026")
 		if err != nil {
 			t.Fatalf("DiscoverDates() error = %v", err)
 		}
@@ -350,7 +362,7 @@ func TestDiscoverDates(t *testing.T) {
 
 	// Test filtering by month
 	t.Run("FilterByMonth", func(t *testing.T) {
-		result, err := DiscoverDates(tmpDir, "2026-02")
+		result, err := store.DiscoverDates("2026-02")
 		if err != nil {
 			t.Fatalf("DiscoverDates() error = %v", err)
 		}
@@ -371,7 +383,7 @@ func TestDiscoverDates(t *testing.T) {
 
 	// Test invalid filter format
 	t.Run("InvalidFilter", func(t *testing.T) {
-		_, err := DiscoverDates(tmpDir, "invalid")
+		_, err := store.DiscoverDates("invalid")
 		if err == nil {
 			t.Error("DiscoverDates() with invalid filter should return error")
 		}
@@ -379,8 +391,8 @@ func TestDiscoverDates(t *testing.T) {
 
 	// Test empty directory
 	t.Run("EmptyDirectory", func(t *testing.T) {
-		emptyDir := t.TempDir()
-		result, err := DiscoverDates(emptyDir, "")
+		empty := &Store{Dir: t.TempDir()}
+		result, err := empty.DiscoverDates("")
 		if err != nil {
 			t.Fatalf("DiscoverDates() error = %v", err)
 		}
@@ -392,8 +404,8 @@ func TestDiscoverDates(t *testing.T) {
 
 	// Test plan file with no date entries
 	t.Run("FileWithNoDates", func(t *testing.T) {
-		tmpDir2 := t.TempDir()
-		file := filepath.Join(tmpDir2, "2026-03.plan")
+		noDates := &Store{Dir: t.TempDir()}
+		file := filepath.Join(noDates.Dir, "2026-03.plan")
 		content := `# 2026-03
 
 Just a preamble
@@ -402,7 +414,7 @@ Just a preamble
 			t.Fatalf("Failed to create test file: %v", err)
 		}
 
-		result, err := DiscoverDates(tmpDir2, "")
+		result, err := noDates.DiscoverDates("")
 		if err != nil {
 			t.Fatalf("DiscoverDates() error = %v", err)
 		}
@@ -412,3 +424,82 @@ Just a preamble
 		}
 	})
 }
+
+func TestAppendLines(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
+	filePath := filepath.Join(tmpDir, "2026-02.plan")
+	original := `# 2026-02
+
+## 2026-02-20
+* Later day
+
+
+## 2026-02-10 - Out of order
+* First entry
+
+* Spaced entry
+
+
+## Notes
+Not a day, kept as written
+`
+	if err := os.WriteFile(filePath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := store.AppendLines(time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC), "Other preamble", []string{"* Appended"})
+	if err != nil || created != (Created{}) {
+		t.Fatalf("AppendLines() = %+v, %v", created, err)
+	}
+	created, err = store.AppendLines(time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC), "", []string{"* Middle"})
+	if err != nil || created != (Created{Day: true}) {
+		t.Fatalf("AppendLines() new day = %+v, %v", created, err)
+	}
+	created, err = store.AppendLines(time.Date(2026, 2, 25, 0, 0, 0, 0, time.UTC), "", []string{"* Last"})
+	if err != nil || created != (Created{Day: true}) {
+		t.Fatalf("AppendLines() last day = %+v, %v", created, err)
+	}
+
+	want := `# 2026-02
+
+## 2026-02-15
+* Middle
+
+
+## 2026-02-20
+* Later day
+
+
+## 2026-02-10 - Out of order
+* First entry
+
+* Spaced entry
+* Appended
+
+
+## 2026-02-25
+* Last
+
+
+## Notes
+Not a day, kept as written
+`
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != want {
+		t.Errorf("file after AppendLines() =\n%s\nwant\n%s", content, want)
+	}
+
+	// A new month file gets the preamble
+	created, err = store.AppendLines(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), "Preamble", []string{"* New"})
+	if err != nil || created != (Created{Month: true, Day: true}) {
+		t.Fatalf("AppendLines() new month = %+v, %v", created, err)
+	}
+	content, _ = os.ReadFile(filepath.Join(tmpDir, "2026-03.plan"))
+	if want := "# 2026-03\n\nPreamble\n\n\n## 2026-03-01\n* New\n"; string(content) != want {
+		t.Errorf("new month file = %q, want %q", content, want)
+	}
+}
@@ -1,11 +1,11 @@
 package planfile
 
 import (
-	"bufio"
 	"fmt"
 	"os"
-	"regexp"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/abyss/plan-journal-cli/pkg/dateutil"
 )
@@ -26,54 +26,76 @@ type PlanFile struct {
 }
 
 // ParseFile parses a plan file into sections
-func ParseFile(filePath string) (*PlanFile, error) {
-	file, err := os.Open(filePath)
+func (s *Store) ParseFile(filePath string) (*PlanFile, error) {
+	content, err := s.readPlan(filePath)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
+	return parsePlan(string(content), false), nil
+}
+
+// ScanHeaders reads only the month header and date headers of a plan file
+// Dates has an entry for every date, without content, and Preamble is left empty
+// Use it when only the dates or titles are needed
+func (s *Store) ScanHeaders(filePath string) (*PlanFile, error) {
+	content, err := s.readPlan(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return parsePlan(string(content), true), nil
+}
 
+// parsePlan parses plan file content; lines are slices of content, not copies
+// With headersOnly, content and preamble lines are skipped
+func parsePlan(content string, headersOnly bool) *PlanFile {
 	pf := &PlanFile{
 		Dates:       make(map[string][]string),
 		DateOrder:   []string{},
 		DateHeaders: make(map[string]string),
 	}
 
-	scanner := bufio.NewScanner(file)
 	var currentSection string
+	var sectionLines []string
 	var preambleLines []string
 	inPreamble := false
 
-	for scanner.Scan() {
-		line := scanner.Text()
+	// flush stores the lines collected for the current date section
+	flush := func() {
+		if currentSection != "" {
+			pf.Dates[currentSection] = sectionLines
+		}
+	}
+
+	for content != "" {
+		var line string
+		line, content, _ = strings.Cut(content, "\n")
+		line = strings.TrimSuffix(line, "\r")
+		if headersOnly {
+			content = skipToHeader(content)
+		}
 
 		// Month header (# YYYY-MM)
-		if strings.HasPrefix(line, "# ") && !strings.HasPrefix(line, "## ") {
+		if strings.HasPrefix(line, "# ") {
 			pf.MonthHeader = line
 			inPreamble = true
 			continue
 		}
 
 		// Date header (## YYYY-MM-DD [optional text])
-		if strings.HasPrefix(line, "## ") {
+		if headerText, found := strings.CutPrefix(line, "## "); found {
 			inPreamble = false
-			headerText := strings.TrimPrefix(line, "## ")
 
 			// Extract just the date (YYYY-MM-DD) from the beginning
-			datePattern := regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})`)
-			matches := datePattern.FindStringSubmatch(headerText)
-
-			if len(matches) > 0 {
-				date := matches[1]
-
+			if date, ok := headerDate(headerText); ok {
 				// Validate the extracted date
 				if !dateutil.IsValidDate(date) {
 					fmt.Fprintf(os.Stderr, "Warning: Invalid date '%s' in header '%s'\n", date, line)
 				}
 
+				flush()
 				currentSection = date
+				sectionLines = []string{}
 				pf.DateOrder = append(pf.DateOrder, date)
-				pf.Dates[date] = []string{}
 				pf.DateHeaders[date] = line // Store full header line as-is
 			}
 			continue
@@ -82,62 +104,134 @@ func ParseFile(filePath string) (*PlanFile, error) {
 		// Content lines
 		if currentSection != "" {
 			// Add to current date section
-			pf.Dates[currentSection] = append(pf.Dates[currentSection], line)
+			sectionLines = append(sectionLines, line)
 		} else if inPreamble && line != "" {
 			// Collect non-empty preamble lines
 			preambleLines = append(preambleLines, line)
 		}
 	}
+	flush()
 
 	// Join preamble lines
 	if len(preambleLines) > 0 {
 		pf.Preamble = strings.Join(preambleLines, "\n")
 	}
 
-	return pf, scanner.Err()
+	return pf
+}
+
+// skipToHeader returns content from the next line that starts with #, or "" if there is none
+func skipToHeader(content string) string {
+	if strings.HasPrefix(content, "#") {
+		return content
+	}
+	if i := strings.Index(content, "\n#"); i >= 0 {
+		return content[i+1:]
+	}
+	return ""
+}
+
+// headerDate returns the YYYY-MM-DD at the start of a date header's text
+// Only the shape is checked; the caller validates the date itself
+func headerDate(text string) (string, bool) {
+	if len(text) < len("2006-01-02") {
+		return "", false
+	}
+	for i := 0; i < len("2006-01-02"); i++ {
+		c := text[i]
+		if i == 4 || i == 7 {
+			if c != '-' {
+				return "", false
+			}
+		} else if c < '0' || c > '9' {
+			return "", false
+		}
+	}
+	return text[:len("2006-01-02")], true
+}
+
+// parseWorkers caps how many files parseFiles reads at once
+var parseWorkers = min(runtime.GOMAXPROCS(0), 8)
+
+// parseFiles parses plan files in parallel, returning them in the order of paths
+// Files that can't be read are nil, except that failing to unlock an encrypted journal
+// is returned as an error. With headersOnly, files are read with ScanHeaders
+func (s *Store) parseFiles(paths []string, headersOnly bool) ([]*PlanFile, error) {
+	results := make([]*PlanFile, len(paths))
+	if len(paths) == 0 {
+		return results, nil
+	}
+
+	// Unlock an encrypted journal up front, so a passphrase prompt happens only once
+	if s.Encrypted() {
+		if _, err := s.keyring(); err != nil {
+			return nil, err
+		}
+	}
+
+	parse := s.ParseFile
+	if headersOnly {
+		parse = s.ScanHeaders
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(parseWorkers, len(paths)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if pf, err := parse(paths[i]); err == nil {
+					results[i] = pf
+				}
+			}
+		}()
+	}
+	for i := range paths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results, nil
 }
 
 // FindDateSectionLine finds the line number where a date section starts
 // Returns 0 if not found
-func FindDateSectionLine(filePath, date string) (int, error) {
-	file, err := os.Open(filePath)
+func (s *Store) FindDateSectionLine(filePath, date string) (int, error) {
+	content, err := s.readPlan(filePath)
 	if err != nil {
 		return 0, err
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
 	lineNum := 0
 	datePrefix := "## " + date
 
-	for scanner.Scan() {
+	for line := range strings.Lines(string(content)) {
 		lineNum++
-		if strings.HasPrefix(scanner.Text(), datePrefix) {
+		if strings.HasPrefix(line, datePrefix) {
 			return lineNum, nil
 		}
 	}
 
-	return 0, scanner.Err()
+	return 0, nil
 }
 
 // FindInsertionLineForDate finds the line number where new entries should be added for a date
 // Returns the line after the last entry for that date
-func FindInsertionLineForDate(filePath, date string) (int, error) {
-	file, err := os.Open(filePath)
+func (s *Store) FindInsertionLineForDate(filePath, date string) (int, error) {
+	content, err := s.readPlan(filePath)
 	if err != nil {
 		return 0, err
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
 	lineNum := 0
 	datePrefix := "## " + date
 	inTargetSection := false
 	lastContentLine := 0
 
-	for scanner.Scan() {
+	for line := range strings.Lines(string(content)) {
 		lineNum++
-		line := scanner.Text()
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
 
 		// Found our date header
 		if strings.HasPrefix(line, datePrefix) {
@@ -158,12 +252,12 @@ func FindInsertionLineForDate(filePath, date string) (int, error) {
 	}
 
 	// Return line after last content (insertion point)
-	return lastContentLine + 1, scanner.Err()
+	return lastContentLine + 1, nil
 }
 
 // ExtractDateContent extracts the content lines for a specific date
-func ExtractDateContent(filePath, date string) (string, error) {
-	pf, err := ParseFile(filePath)
+func (s *Store) ExtractDateContent(filePath, date string) (string, error) {
+	pf, err := s.ParseFile(filePath)
 	if err != nil {
 		return "", err
 	}
@@ -179,12 +273,11 @@ func ExtractDateContent(filePath, date string) (string, error) {
 	if !ok {
 		header = "## " + date
 	}
+	return SectionText(header, content), nil
+}
 
-	if len(content) == 0 {
-		return header, nil
-	}
-
-	// Filter out empty lines and join with header
+// SectionText joins a date header and its non-empty content lines, as plan read shows them
+func SectionText(header string, content []string) string {
 	var lines []string
 	for _, line := range content {
 		if line != "" {
@@ -193,8 +286,8 @@ func ExtractDateContent(filePath, date string) (string, error) {
 	}
 
 	if len(lines) == 0 {
-		return header, nil
+		return header
 	}
 
-	return header + "\n" + strings.Join(lines, "\n"), nil
+	return header + "\n" + strings.Join(lines, "\n")
 }
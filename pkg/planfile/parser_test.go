@@ -2,6 +2,7 @@ package planfile
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -12,6 +13,7 @@ import (
 func TestParseFile(t *testing.T) {
 	// Create temporary test file
 	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
 	testFile := filepath.Join(tmpDir, "2026-02.plan")
 
 	content := `# 2026-02
@@ -30,7 +32,7 @@ Test preamble text
 		t.Fatalf("Failed to create test file: %v", err)
 	}
 
-	pf, err := ParseFile(testFile)
+	pf, err := store.ParseFile(testFile)
 	if err != nil {
 		t.Fatalf("ParseFile() error = %v", err)
 	}
@@ -65,6 +67,7 @@ Test preamble text
 
 func TestExtractDateContent(t *testing.T) {
 	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
 	testFile := filepath.Join(tmpDir, "2026-02.plan")
 
 	content := `# 2026-02
@@ -83,7 +86,7 @@ Test preamble
 		t.Fatalf("Failed to create test file: %v", err)
 	}
 
-	result, err := ExtractDateContent(testFile, "2026-02-13")
+	result, err := store.ExtractDateContent(testFile, "2026-02-13")
 	if err != nil {
 		t.Fatalf("ExtractDateContent() error = %v", err)
 	}
@@ -113,6 +116,7 @@ Test preamble
 
 func TestFindInsertionLineForDate(t *testing.T) {
 	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
 	testFile := filepath.Join(tmpDir, "2026-02.plan")
 
 	content := `# 2026-02
@@ -131,7 +135,7 @@ Test preamble
 		t.Fatalf("Failed to create test file: %v", err)
 	}
 
-	line, err := FindInsertionLineForDate(testFile, "2026-02-13")
+	line, err := store.FindInsertionLineForDate(testFile, "2026-02-13")
 	if err != nil {
 		t.Fatalf("FindInsertionLineForDate() error = %v", err)
 	}
@@ -145,6 +149,7 @@ Test preamble
 func TestParseFileWithInvalidDate(t *testing.T) {
 	// Create temporary test file with an invalid date
 	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
 	testFile := filepath.Join(tmpDir, "2026-02.plan")
 
 	content := `# 2026-02
@@ -171,7 +176,7 @@ Test preamble
 	os.Stderr = w
 
 	// Parse file
-	pf, err := ParseFile(testFile)
+	pf, err := store.ParseFile(testFile)
 
 	// Restore stderr and read captured output
 	w.Close()
@@ -206,3 +211,92 @@ Test preamble
 		t.Errorf("DateOrder = %v, want %v", pf.DateOrder, expectedOrder)
 	}
 }
+
+func TestScanHeaders(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
+	testFile := filepath.Join(tmpDir, "2026-02.plan")
+	content := "# 2026-02\r\n\r\nPreamble\r\n\r\n## 2026-02-13 - Title\r\n* Entry\r\n\r\n## Notes\r\n## 2026-02-14\r\n* Entry"
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	pf, err := store.ScanHeaders(testFile)
+	if err != nil {
+		t.Fatalf("ScanHeaders() error = %v", err)
+	}
+	if pf.MonthHeader != "# 2026-02" || pf.Preamble != "" {
+		t.Errorf("MonthHeader, Preamble = %q, %q", pf.MonthHeader, pf.Preamble)
+	}
+	if !reflect.DeepEqual(pf.DateOrder, []string{"2026-02-13", "2026-02-14"}) {
+		t.Errorf("DateOrder = %v", pf.DateOrder)
+	}
+	if pf.DateHeaders["2026-02-13"] != "## 2026-02-13 - Title" {
+		t.Errorf("DateHeaders = %v", pf.DateHeaders)
+	}
+	if len(pf.Dates["2026-02-13"]) != 0 {
+		t.Errorf("Dates[2026-02-13] = %v, want no content", pf.Dates["2026-02-13"])
+	}
+
+	// A full parse of the same file strips CRLF, keeps the last line without a newline,
+	// and drops headers without a date as before
+	full, err := store.ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	if !reflect.DeepEqual(full.Dates["2026-02-13"], []string{"* Entry", ""}) || !reflect.DeepEqual(full.Dates["2026-02-14"], []string{"* Entry"}) {
+		t.Errorf("Dates = %q", full.Dates)
+	}
+}
+
+func TestHeaderDate(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+		ok   bool
+	}{
+		{"2026-02-13", "2026-02-13", true},
+		{"2026-02-13 - Title", "2026-02-13", true},
+		{"2026-02-99", "2026-02-99", true},
+		{"2026-2-13", "", false},
+		{"2026/02/13", "", false},
+		{"Notes", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := headerDate(tt.text)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("headerDate(%q) = %q, %v, want %q, %v", tt.text, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
+	var paths []string
+	for month := 1; month <= 12; month++ {
+		name := fmt.Sprintf("2026-%02d", month)
+		path := filepath.Join(tmpDir, name+".plan")
+		content := fmt.Sprintf("# %s\n\n## %s-01\n* Entry\n", name, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	paths = append(paths, filepath.Join(tmpDir, "missing.plan"))
+
+	results, err := store.parseFiles(paths, false)
+	if err != nil {
+		t.Fatalf("parseFiles() error = %v", err)
+	}
+	for i, pf := range results[:12] {
+		want := fmt.Sprintf("2026-%02d-01", i+1)
+		if pf == nil || !reflect.DeepEqual(pf.DateOrder, []string{want}) {
+			t.Errorf("results[%d] = %+v, want %s", i, pf, want)
+		}
+	}
+	if results[12] != nil {
+		t.Errorf("results[12] = %+v, want nil for a missing file", results[12])
+	}
+}
@@ -0,0 +1,189 @@
+package planfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+)
+
+// ScopedEdit tracks a single date section extracted to a temporary file for editing
+type ScopedEdit struct {
+	FilePath string // The month file the section belongs to
+	TempPath string // The temporary file holding the section
+	Date     string // YYYY-MM-DD
+
+	originalHash    string
+	originalHeader  string
+	originalContent []string
+	store           *Store
+}
+
+// StartScopedEdit extracts a date section into a private temporary file
+// The date section must already exist in the month file
+func (s *Store) StartScopedEdit(date time.Time) (*ScopedEdit, error) {
+	filePath := filepath.Join(s.Dir, dateutil.MonthFileName(date))
+	dateStr := dateutil.FormatDate(date)
+
+	hash, err := s.HashFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan file: %w", err)
+	}
+
+	pf, err := s.ParseFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+	content, ok := pf.Dates[dateStr]
+	if !ok {
+		return nil, fmt.Errorf("date section %s not found", dateStr)
+	}
+	header, ok := pf.DateHeaders[dateStr]
+	if !ok {
+		header = "## " + dateStr
+	}
+	content = trimTrailingEmptyLines(content)
+
+	// The section is only readable by the user, in a directory only the user can open
+	body := strings.Join(append([]string{header}, content...), "\n") + "\n"
+	tempPath, err := writePrivateTemp(dateStr+".plan", []byte(body))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScopedEdit{
+		FilePath:        filePath,
+		TempPath:        tempPath,
+		Date:            dateStr,
+		originalHash:    hash,
+		originalHeader:  header,
+		originalContent: content,
+		store:           s,
+	}, nil
+}
+
+// ReadEdited parses the temporary file back into a header and content lines
+// The header may gain a title but must keep its date; other date sections are rejected
+func (s *ScopedEdit) ReadEdited() (string, []string, error) {
+	data, err := os.ReadFile(s.TempPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read edited section: %w", err)
+	}
+
+	header := ""
+	var content []string
+	for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+		if strings.HasPrefix(line, "## ") {
+			if header != "" || !strings.HasPrefix(line, "## "+s.Date) {
+				return "", nil, fmt.Errorf("edited section may only contain the %s header, found %q", s.Date, line)
+			}
+			header = line
+			continue
+		}
+		if header == "" && line == "" {
+			// Skip blank lines above the header
+			continue
+		}
+		content = append(content, line)
+	}
+
+	// Header deleted: keep the original one
+	if header == "" {
+		header = s.originalHeader
+	}
+	return header, trimTrailingEmptyLines(content), nil
+}
+
+// Modified reports whether the edited section differs from what was extracted
+func (s *ScopedEdit) Modified(header string, content []string) bool {
+	if header != s.originalHeader || len(content) != len(s.originalContent) {
+		return true
+	}
+	for i := range content {
+		if content[i] != s.originalContent[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// ConflictOnDisk reports whether the month file changed since the section was extracted
+func (s *ScopedEdit) ConflictOnDisk() (bool, error) {
+	hash, err := s.store.HashFile(s.FilePath)
+	if err != nil {
+		return false, err
+	}
+	return hash != s.originalHash, nil
+}
+
+// Merge writes the edited section back into the current month file through the writer
+// Other date sections are taken from the file as it is on disk now. Lines appended to the
+// day meanwhile (e.g. by plan add or plan serve) are kept after the edited content; if the
+// day changed on disk in any other way, nothing is written and ErrChangedOnDisk is returned
+func (s *ScopedEdit) Merge(header string, content []string) error {
+	unlock, err := s.store.lockDirectory(filepath.Dir(s.FilePath))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	pf, err := s.store.ParseFile(s.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	current, exists := pf.Dates[s.Date]
+	current = trimTrailingEmptyLines(current)
+	if !exists || pf.DateHeaders[s.Date] != s.originalHeader ||
+		len(current) < len(s.originalContent) || !slices.Equal(current[:len(s.originalContent)], s.originalContent) {
+		return ErrChangedOnDisk
+	}
+	appended := current[len(s.originalContent):]
+
+	pf.Dates[s.Date] = append(slices.Clone(content), appended...)
+	pf.DateHeaders[s.Date] = header
+
+	return s.store.WritePlanFile(s.FilePath, pf)
+}
+
+// Reject keeps the edited section next to the month file as <file>.rej, or
+// <file>.2.rej and so on if earlier reject files exist (encrypted like the month file
+// in an encrypted journal). Returns the path of the reject file
+func (s *ScopedEdit) Reject() (string, error) {
+	data, err := os.ReadFile(s.TempPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited section: %w", err)
+	}
+	return s.store.writeReject(s.FilePath, data)
+}
+
+// writeReject writes an edit that couldn't be saved to the first free reject file of a
+// plan file, returning its stored path
+func (s *Store) writeReject(filePath string, data []byte) (string, error) {
+	var rejPath string
+	err := s.withLock(filepath.Dir(filePath), func() error {
+		rejPath = filePath + ".rej"
+		for n := 2; ; n++ {
+			if _, err := s.statPlan(rejPath); os.IsNotExist(err) {
+				break
+			} else if err != nil {
+				return err
+			}
+			rejPath = fmt.Sprintf("%s.%d.rej", filePath, n)
+		}
+		return s.writePlan(rejPath, data, 0600)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to write reject file: %w", err)
+	}
+	return s.StoredPath(rejPath), nil
+}
+
+// Cleanup securely removes the temporary file and its directory
+func (s *ScopedEdit) Cleanup() error {
+	return removePrivateTemp(s.TempPath)
+}
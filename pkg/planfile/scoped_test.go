@@ -0,0 +1,176 @@
+package planfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const scopedTestContent = `# 2026-02
+
+## 2026-02-13
+* Entry 1
+
+
+## 2026-02-14
+* Entry 2
+`
+
+func startScopedTest(t *testing.T) (string, *ScopedEdit) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
+	testFile := filepath.Join(tmpDir, "2026-02.plan")
+	if err := os.WriteFile(testFile, []byte(scopedTestContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	edit, err := store.StartScopedEdit(time.Date(2026, 2, 13, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("StartScopedEdit() error = %v", err)
+	}
+	t.Cleanup(func() { edit.Cleanup() })
+	return testFile, edit
+}
+
+func TestScopedEditMerge(t *testing.T) {
+	testFile, edit := startScopedTest(t)
+
+	extracted, err := os.ReadFile(edit.TempPath)
+	if err != nil {
+		t.Fatalf("Failed to read temp file: %v", err)
+	}
+	if string(extracted) != "## 2026-02-13\n* Entry 1\n" {
+		t.Errorf("temp file = %q, want only the 2026-02-13 section", extracted)
+	}
+	if info, _ := os.Stat(edit.TempPath); info.Mode().Perm() != 0600 {
+		t.Errorf("temp file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	// Simulate the editor: add a title and an entry
+	if err := os.WriteFile(edit.TempPath, []byte("## 2026-02-13 - Focus\n* Entry 1\n* Entry 1b\n\n"), 0600); err != nil {
+		t.Fatalf("Failed to edit temp file: %v", err)
+	}
+
+	header, content, err := edit.ReadEdited()
+	if err != nil {
+		t.Fatalf("ReadEdited() error = %v", err)
+	}
+	if !edit.Modified(header, content) {
+		t.Error("Modified() = false, want true")
+	}
+
+	conflict, err := edit.ConflictOnDisk()
+	if err != nil || conflict {
+		t.Errorf("ConflictOnDisk() = %v, %v, want false", conflict, err)
+	}
+
+	if err := edit.Merge(header, content); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	merged, _ := os.ReadFile(testFile)
+	want := "# 2026-02\n\n## 2026-02-13 - Focus\n* Entry 1\n* Entry 1b\n\n\n## 2026-02-14\n* Entry 2\n"
+	if string(merged) != want {
+		t.Errorf("merged file = %q, want %q", merged, want)
+	}
+}
+
+func TestScopedEditConflict(t *testing.T) {
+	testFile, edit := startScopedTest(t)
+
+	// Someone else edits another day meanwhile
+	changed := strings.Replace(scopedTestContent, "* Entry 2", "* Entry 2 (edited elsewhere)", 1)
+	if err := os.WriteFile(testFile, []byte(changed), 0644); err != nil {
+		t.Fatalf("Failed to modify month file: %v", err)
+	}
+
+	conflict, err := edit.ConflictOnDisk()
+	if err != nil || !conflict {
+		t.Errorf("ConflictOnDisk() = %v, %v, want true", conflict, err)
+	}
+
+	rejPath, err := edit.Reject()
+	if err != nil {
+		t.Fatalf("Reject() error = %v", err)
+	}
+	if rejPath != testFile+".rej" {
+		t.Errorf("Reject() path = %v, want %v", rejPath, testFile+".rej")
+	}
+
+	// A second reject keeps the first one
+	if err := os.WriteFile(edit.TempPath, []byte("## 2026-02-13\n* Second try\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	rejPath2, err := edit.Reject()
+	if err != nil || rejPath2 != testFile+".2.rej" {
+		t.Errorf("Reject() again = %v, %v, want %v", rejPath2, err, testFile+".2.rej")
+	}
+	if first, _ := os.ReadFile(rejPath); strings.Contains(string(first), "Second try") {
+		t.Error("Reject() overwrote the earlier reject file")
+	}
+
+	// Merging after a conflict keeps the other day's change
+	if err := edit.Merge("## 2026-02-13", []string{"* Entry 1", "* Added"}); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	merged, _ := os.ReadFile(testFile)
+	if !strings.Contains(string(merged), "edited elsewhere") || !strings.Contains(string(merged), "* Added") {
+		t.Errorf("merged file lost changes: %q", merged)
+	}
+}
+
+func TestScopedEditMergeSameDay(t *testing.T) {
+	testFile, edit := startScopedTest(t)
+	date := time.Date(2026, 2, 13, 0, 0, 0, 0, time.UTC)
+
+	// An entry appended to the day meanwhile is kept after the edit
+	if _, err := edit.store.AppendLines(date, "", []string{"* Appended meanwhile"}); err != nil {
+		t.Fatalf("AppendLines() error = %v", err)
+	}
+	if err := edit.Merge("## 2026-02-13", []string{"* Entry 1 (edited)"}); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	lines, _ := edit.store.DateSectionLines(testFile, "2026-02-13")
+	if want := []string{"* Entry 1 (edited)", "* Appended meanwhile"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("merged day = %q, want %q", lines, want)
+	}
+
+	// A day rewritten on disk isn't overwritten
+	_, edit = startScopedTest(t)
+	testFile = edit.FilePath
+	changed := strings.Replace(scopedTestContent, "* Entry 1", "* Entry 1 (changed elsewhere)", 1)
+	if err := os.WriteFile(testFile, []byte(changed), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := edit.Merge("## 2026-02-13", []string{"* Mine"}); !errors.Is(err, ErrChangedOnDisk) {
+		t.Errorf("Merge() error = %v, want ErrChangedOnDisk", err)
+	}
+	if data, _ := os.ReadFile(testFile); string(data) != changed {
+		t.Errorf("Merge() changed the file:\n%s", data)
+	}
+}
+
+func TestScopedEditRejectsOtherSections(t *testing.T) {
+	_, edit := startScopedTest(t)
+
+	if err := os.WriteFile(edit.TempPath, []byte("## 2026-02-13\n* ok\n## 2026-02-20\n* sneaky\n"), 0600); err != nil {
+		t.Fatalf("Failed to edit temp file: %v", err)
+	}
+	if _, _, err := edit.ReadEdited(); err == nil {
+		t.Error("ReadEdited() error = nil, want error for extra date section")
+	}
+
+	// Deleting the header keeps the original one
+	if err := os.WriteFile(edit.TempPath, []byte("* only content\n"), 0600); err != nil {
+		t.Fatalf("Failed to edit temp file: %v", err)
+	}
+	header, content, err := edit.ReadEdited()
+	if err != nil || header != "## 2026-02-13" || len(content) != 1 {
+		t.Errorf("ReadEdited() = %q, %q, %v", header, content, err)
+	}
+}
@@ -0,0 +1,165 @@
+package planfile
+
+import (
+	"sort"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+)
+
+// Stats summarizes journaling habits over a range of days
+// Only days with at least one non-empty line count as entries
+type Stats struct {
+	From              string       `json:"from"`
+	To                string       `json:"to"`
+	Entries           int          `json:"entries"`
+	CurrentStreak     int          `json:"current_streak"`
+	LongestStreak     int          `json:"longest_streak"`
+	LongestStreakEnd  string       `json:"longest_streak_end,omitempty"`
+	EntriesPerWeekday [7]int       `json:"-"` // Indexed by time.Weekday
+	TotalWords        int          `json:"total_words"`
+	AverageWords      float64      `json:"average_words"`
+	BusiestMonths     []MonthCount `json:"busiest_months"`
+	Workdays          int          `json:"workdays"`
+	WorkdaysLogged    int          `json:"workdays_logged"`
+	OpenTasks         int          `json:"open_tasks"`
+	DoneTasks         int          `json:"done_tasks"`
+}
+
+// MonthCount is the activity of one month
+type MonthCount struct {
+	Month   string `json:"month"`
+	Entries int    `json:"entries"`
+	Words   int    `json:"words"`
+}
+
+// BusiestMonthsLimit is how many months ComputeStats reports as busiest
+const BusiestMonthsLimit = 3
+
+// ComputeStats computes statistics for days between from and to (inclusive)
+// Workdays are only counted up to today, and the current streak may end today or yesterday
+func ComputeStats(days []DaySummary, from, to, today time.Time) Stats {
+	stats := Stats{From: dateutil.FormatDate(from), To: dateutil.FormatDate(to)}
+	todayStr := dateutil.FormatDate(today)
+
+	logged := make(map[string]bool)
+	months := make(map[string]*MonthCount)
+	var dates []time.Time
+	for _, day := range days {
+		if day.Lines == 0 || day.Date < stats.From || day.Date > stats.To {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", day.Date)
+		if err != nil {
+			continue
+		}
+
+		stats.Entries++
+		stats.EntriesPerWeekday[date.Weekday()]++
+		stats.TotalWords += day.Words
+		stats.OpenTasks += day.OpenTasks
+		stats.DoneTasks += day.DoneTasks
+		logged[day.Date] = true
+		dates = append(dates, date)
+
+		month := day.Date[:7]
+		if months[month] == nil {
+			months[month] = &MonthCount{Month: month}
+		}
+		months[month].Entries++
+		months[month].Words += day.Words
+	}
+
+	if stats.Entries > 0 {
+		stats.AverageWords = float64(stats.TotalWords) / float64(stats.Entries)
+	}
+
+	// Streaks: runs of consecutive days with entries
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	run := 0
+	for i, date := range dates {
+		if i > 0 && dates[i-1].AddDate(0, 0, 1).Equal(date) {
+			run++
+		} else {
+			run = 1
+		}
+		if run > stats.LongestStreak {
+			stats.LongestStreak = run
+			stats.LongestStreakEnd = dateutil.FormatDate(date)
+		}
+	}
+
+	// A streak is still current if today isn't logged yet
+	day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if !logged[todayStr] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for logged[dateutil.FormatDate(day)] {
+		stats.CurrentStreak++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	// Workdays up to today
+	end := to
+	if todayDate, _ := time.Parse("2006-01-02", todayStr); end.After(todayDate) {
+		end = todayDate
+	}
+	for d := from; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if dateutil.IsWeekday(d) {
+			stats.Workdays++
+			if logged[dateutil.FormatDate(d)] {
+				stats.WorkdaysLogged++
+			}
+		}
+	}
+
+	for _, month := range months {
+		stats.BusiestMonths = append(stats.BusiestMonths, *month)
+	}
+	sort.Slice(stats.BusiestMonths, func(i, j int) bool {
+		a, b := stats.BusiestMonths[i], stats.BusiestMonths[j]
+		if a.Entries != b.Entries {
+			return a.Entries > b.Entries
+		}
+		if a.Words != b.Words {
+			return a.Words > b.Words
+		}
+		return a.Month < b.Month
+	})
+	if len(stats.BusiestMonths) > BusiestMonthsLimit {
+		stats.BusiestMonths = stats.BusiestMonths[:BusiestMonthsLimit]
+	}
+
+	return stats
+}
+
+// WorkdayShare returns the fraction of workdays with an entry (0 if there were none)
+func (s Stats) WorkdayShare() float64 {
+	if s.Workdays == 0 {
+		return 0
+	}
+	return float64(s.WorkdaysLogged) / float64(s.Workdays)
+}
+
+// TaskCompletion returns the fraction of tasks marked done (0 if there are no tasks)
+func (s Stats) TaskCompletion() float64 {
+	total := s.OpenTasks + s.DoneTasks
+	if total == 0 {
+		return 0
+	}
+	return float64(s.DoneTasks) / float64(total)
+}
+
+// HeatLevels is the number of shades HeatLevel maps values to (0 means no activity)
+const HeatLevels = 5
+
+// HeatLevel maps a value to a shade from 0 (none) to HeatLevels-1 (the busiest days)
+// Non-zero values are split into equal quarters of the maximum
+func HeatLevel(value, maxValue int) int {
+	if value <= 0 || maxValue <= 0 {
+		return 0
+	}
+	// Round up so any activity is at least level 1
+	level := (value*(HeatLevels-1) + maxValue - 1) / maxValue
+	return max(1, min(HeatLevels-1, level))
+}
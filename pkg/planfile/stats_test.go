@@ -0,0 +1,82 @@
+package planfile
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeStats(t *testing.T) {
+	days := []DaySummary{
+		{Date: "2026-02-02", Lines: 2, Words: 10, OpenTasks: 1},              // Mon
+		{Date: "2026-02-03", Lines: 1, Words: 4, DoneTasks: 2},               // Tue
+		{Date: "2026-02-04", Lines: 1, Words: 6},                             // Wed
+		{Date: "2026-02-05"},                                                 // Thu, empty header
+		{Date: "2026-02-12", Lines: 3, Words: 20, DoneTasks: 1},              // Thu
+		{Date: "2026-02-13", Lines: 1, Words: 2},                             // Fri
+		{Date: "2026-01-30", Lines: 1, Words: 8},                             // Fri
+		{Date: "2026-03-01", Lines: 1, Words: 1, OpenTasks: 1, DoneTasks: 1}, // Sun, outside range
+	}
+	from := time.Date(2026, 1, 26, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2026, 2, 14, 10, 0, 0, 0, time.Local)
+
+	stats := ComputeStats(days, from, to, today)
+
+	if stats.Entries != 6 {
+		t.Errorf("Entries = %d, want 6", stats.Entries)
+	}
+	if stats.LongestStreak != 3 || stats.LongestStreakEnd != "2026-02-04" {
+		t.Errorf("LongestStreak = %d ending %s, want 3 ending 2026-02-04", stats.LongestStreak, stats.LongestStreakEnd)
+	}
+	// Today (Saturday) isn't logged yet, so the streak ending yesterday still counts
+	if stats.CurrentStreak != 2 {
+		t.Errorf("CurrentStreak = %d, want 2", stats.CurrentStreak)
+	}
+	if stats.EntriesPerWeekday[time.Thursday] != 1 || stats.EntriesPerWeekday[time.Friday] != 2 {
+		t.Errorf("EntriesPerWeekday = %v", stats.EntriesPerWeekday)
+	}
+	if stats.TotalWords != 50 || stats.AverageWords < 8.33 || stats.AverageWords > 8.34 {
+		t.Errorf("TotalWords = %d, AverageWords = %v", stats.TotalWords, stats.AverageWords)
+	}
+	// Workdays from 2026-01-26 to today: 15
+	if stats.Workdays != 15 || stats.WorkdaysLogged != 6 {
+		t.Errorf("Workdays = %d logged %d, want 15 logged 6", stats.Workdays, stats.WorkdaysLogged)
+	}
+	if stats.OpenTasks != 1 || stats.DoneTasks != 3 || stats.TaskCompletion() != 0.75 {
+		t.Errorf("Tasks = %d open %d done (%v)", stats.OpenTasks, stats.DoneTasks, stats.TaskCompletion())
+	}
+	if len(stats.BusiestMonths) != 2 || stats.BusiestMonths[0].Month != "2026-02" || stats.BusiestMonths[0].Entries != 5 {
+		t.Errorf("BusiestMonths = %+v", stats.BusiestMonths)
+	}
+}
+
+func TestComputeStatsEmpty(t *testing.T) {
+	day := time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)
+	stats := ComputeStats(nil, day, day, day)
+	if stats.Entries != 0 || stats.AverageWords != 0 || stats.WorkdayShare() != 0 || stats.TaskCompletion() != 0 {
+		t.Errorf("ComputeStats(nil) = %+v", stats)
+	}
+}
+
+func TestHeatLevel(t *testing.T) {
+	tests := []struct {
+		value, max, want int
+	}{
+		{0, 10, 0},
+		{1, 10, 1},
+		{2, 10, 1},
+		{3, 10, 2},
+		{4, 10, 2},
+		{6, 10, 3},
+		{8, 10, 4},
+		{12, 10, 4},
+		{10, 10, 4},
+		{1, 1, 4},
+		{5, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := HeatLevel(tt.value, tt.max); got != tt.want {
+			t.Errorf("HeatLevel(%d, %d) = %d, want %d", tt.value, tt.max, got, tt.want)
+		}
+	}
+}
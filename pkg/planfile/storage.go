@@ -0,0 +1,455 @@
+package planfile
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/abyss/plan-journal-cli/pkg/vault"
+)
+
+// A plans directory is encrypted when it contains a key file (see vault.KeyFileName)
+// Every plan file in it is then stored as <name>.enc, and the functions in this package
+// read and write the plaintext through the helpers below, so callers keep using
+// YYYY-MM.plan paths and decrypted content only ever lives in memory
+
+// ErrNoPassphrase is returned when an encrypted journal is used without a passphrase source
+var ErrNoPassphrase = errors.New("journal is encrypted: set PLAN_PASSPHRASE or PLAN_PASSPHRASE_COMMAND, or run in a terminal")
+
+type keyringResult struct {
+	keyring *vault.Keyring
+	err     error
+}
+
+// Unlock checks a passphrase against an encrypted directory's key file and uses it
+// for the Store from then on, instead of asking Store.Passphrase
+func (s *Store) Unlock(passphrase string) error {
+	keyFile, err := s.readFile(filepath.Join(s.Dir, vault.KeyFileName))
+	if err != nil {
+		return fmt.Errorf("failed to read key file: %w", err)
+	}
+	keyring, err := vault.OpenKeyring(passphrase, keyFile)
+	if err != nil {
+		return err
+	}
+	s.setKeyring(&keyringResult{keyring: keyring})
+	return nil
+}
+
+// Encrypted reports whether the plans directory is encrypted
+func (s *Store) Encrypted() bool {
+	_, err := s.statFile(filepath.Join(s.Dir, vault.KeyFileName))
+	return err == nil
+}
+
+// StoredPath returns the path a plan file is stored at on disk
+// This is the .enc file for plan files in an encrypted directory
+func (s *Store) StoredPath(filePath string) string {
+	if s.encryptedPath(filePath) {
+		return filePath + vault.Extension
+	}
+	return filePath
+}
+
+// IsUnlockError reports whether err came from unlocking an encrypted journal
+func IsUnlockError(err error) bool {
+	return errors.Is(err, ErrNoPassphrase) || errors.Is(err, vault.ErrWrongPassphrase)
+}
+
+// encryptedPath reports whether a plan file path is in the plans directory and it is encrypted
+// Files elsewhere, such as temporary copies for editors, are always plain
+func (s *Store) encryptedPath(filePath string) bool {
+	return !strings.HasSuffix(filePath, vault.Extension) &&
+		filepath.Clean(filepath.Dir(filePath)) == filepath.Clean(s.Dir) && s.Encrypted()
+}
+
+// keyring unlocks an encrypted directory, asking for the passphrase once
+// The lock is held while asking, so concurrent readers wait for the one prompt
+func (s *Store) keyring() (*vault.Keyring, error) {
+	s.keyMu.Lock()
+	defer s.keyMu.Unlock()
+
+	if s.key != nil {
+		return s.key.keyring, s.key.err
+	}
+
+	keyring, err := s.openKeyring()
+	s.key = &keyringResult{keyring, err}
+	return keyring, err
+}
+
+// openKeyring checks the passphrase from Store.Passphrase against the key file
+func (s *Store) openKeyring() (*vault.Keyring, error) {
+	keyFile, err := s.readFile(filepath.Join(s.Dir, vault.KeyFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	if s.Passphrase == nil {
+		return nil, ErrNoPassphrase
+	}
+	passphrase, err := s.Passphrase()
+	if err != nil {
+		return nil, err
+	}
+	return vault.OpenKeyring(passphrase, keyFile)
+}
+
+// ReadFile reads a plan file by its plaintext path, decrypting it in an encrypted journal
+func (s *Store) ReadFile(filePath string) ([]byte, error) {
+	return s.readPlan(filePath)
+}
+
+// readPlan reads a plan file, decrypting it if it is stored encrypted
+// A missing file reports an os.IsNotExist error either way
+func (s *Store) readPlan(filePath string) ([]byte, error) {
+	if !s.encryptedPath(filePath) {
+		return s.readFile(filePath)
+	}
+
+	data, err := s.readFile(filePath + vault.Extension)
+	if err != nil {
+		return nil, err
+	}
+	keyring, err := s.keyring()
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := keyring.Decrypt(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", filepath.Base(filePath), err)
+	}
+	return plaintext, nil
+}
+
+// writePlan writes a plan file, encrypting it if its directory is encrypted
+// The file is replaced atomically (see replaceFile), so a failed write can't leave a
+// truncated plan file or ciphertext behind
+func (s *Store) writePlan(filePath string, content []byte, perm os.FileMode) error {
+	if !s.encryptedPath(filePath) {
+		return s.replaceFile(filePath, content, perm)
+	}
+
+	keyring, err := s.keyring()
+	if err != nil {
+		return err
+	}
+	sealed, err := keyring.Encrypt(content)
+	if err != nil {
+		return err
+	}
+	return s.replaceFile(filePath+vault.Extension, sealed, 0600)
+}
+
+// statPlan returns file info for the stored form of a plan file
+func (s *Store) statPlan(filePath string) (os.FileInfo, error) {
+	return s.statFile(s.StoredPath(filePath))
+}
+
+// planFileNames lists the plan files in the plans directory by their plaintext names (YYYY-MM.plan)
+// Only .enc files are listed in an encrypted directory, and only plain ones otherwise
+func (s *Store) planFileNames() ([]string, error) {
+	entries, err := s.readDir(s.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted := s.Encrypted()
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if encrypted {
+			plain, found := strings.CutSuffix(name, vault.Extension)
+			if !found {
+				continue
+			}
+			name = plain
+		}
+		if strings.HasSuffix(name, ".plan") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// EncryptDirectory encrypts every plain plan file in the plans directory with a passphrase
+// A new directory gets a key file for the passphrase; a directory that is already
+// encrypted must use the same passphrase, so an interrupted migration can be resumed.
+// Each file is verified after encryption before its plaintext is securely removed.
+// Returns the names of the files that were encrypted
+func (s *Store) EncryptDirectory(passphrase string) ([]string, error) {
+	dir := filepath.Clean(s.Dir)
+	unlock, err := s.lockDirectory(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	keyPath := filepath.Join(dir, vault.KeyFileName)
+
+	var keyring *vault.Keyring
+	if keyFile, err := s.readFile(keyPath); err == nil {
+		if keyring, err = vault.OpenKeyring(passphrase, keyFile); err != nil {
+			return nil, fmt.Errorf("journal is already encrypted with a different passphrase: %w", err)
+		}
+	} else if os.IsNotExist(err) {
+		var keyFile []byte
+		if keyring, keyFile, err = vault.NewKeyring(passphrase); err != nil {
+			return nil, err
+		}
+		if err := s.writeFile(keyPath, keyFile, 0600); err != nil {
+			return nil, fmt.Errorf("failed to write key file: %w", err)
+		}
+	} else {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	s.setKeyring(&keyringResult{keyring: keyring})
+
+	// The plain index lists titles and tags; it is rebuilt encrypted on next use
+	if err := s.removeIndex(); err != nil {
+		return nil, fmt.Errorf("failed to remove index: %w", err)
+	}
+
+	entries, err := s.readDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plans directory: %w", err)
+	}
+
+	var encrypted []string
+	for _, entry := range entries {
+		if entry.IsDir() || !hasStoredSuffix(entry.Name()) {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		content, err := s.readFile(path)
+		if err != nil {
+			return encrypted, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+		if err := s.writePlan(path, content, 0600); err != nil {
+			return encrypted, fmt.Errorf("failed to encrypt %s: %w", entry.Name(), err)
+		}
+		if roundTrip, err := s.readPlan(path); err != nil || !bytes.Equal(roundTrip, content) {
+			return encrypted, fmt.Errorf("failed to verify encrypted %s, the plain file was kept", entry.Name())
+		}
+		if err := s.secureRemoveFile(path); err != nil {
+			return encrypted, fmt.Errorf("failed to remove plain %s: %w", entry.Name(), err)
+		}
+		encrypted = append(encrypted, entry.Name())
+	}
+	return encrypted, nil
+}
+
+// DecryptDirectory turns an encrypted plans directory back into plain plan files
+// The key file is removed last, once every file has been decrypted
+// Returns the names of the files that were decrypted
+func (s *Store) DecryptDirectory(passphrase string) ([]string, error) {
+	dir := filepath.Clean(s.Dir)
+	unlock, err := s.lockDirectory(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	keyPath := filepath.Join(dir, vault.KeyFileName)
+
+	keyFile, err := s.readFile(keyPath)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("journal is not encrypted: %s", dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	keyring, err := vault.OpenKeyring(passphrase, keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.readDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plans directory: %w", err)
+	}
+
+	var decrypted []string
+	for _, entry := range entries {
+		name, found := strings.CutSuffix(entry.Name(), vault.Extension)
+		if entry.IsDir() || !found || !hasStoredSuffix(name) {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := s.readFile(path)
+		if err != nil {
+			return decrypted, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+		content, err := keyring.Decrypt(data)
+		if err != nil {
+			return decrypted, fmt.Errorf("failed to decrypt %s: %w", entry.Name(), err)
+		}
+		if err := s.writeFile(filepath.Join(dir, name), content, 0644); err != nil {
+			return decrypted, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		if err := s.removeFile(path); err != nil {
+			return decrypted, fmt.Errorf("failed to remove %s: %w", entry.Name(), err)
+		}
+		decrypted = append(decrypted, name)
+	}
+
+	if err := s.removeIndex(); err != nil {
+		return decrypted, fmt.Errorf("failed to remove index: %w", err)
+	}
+	if err := s.removeFile(keyPath); err != nil {
+		return decrypted, fmt.Errorf("failed to remove key file: %w", err)
+	}
+	s.setKeyring(nil)
+	return decrypted, nil
+}
+
+// setKeyring replaces the cached keyring of the Store; nil forgets it
+func (s *Store) setKeyring(result *keyringResult) {
+	s.keyMu.Lock()
+	defer s.keyMu.Unlock()
+	s.key = result
+}
+
+// hasStoredSuffix reports whether a file name is one encryption applies to: month
+// files and edits kept after a conflict (<file>.rej, <file>.2.rej, and so on)
+func hasStoredSuffix(name string) bool {
+	if base, found := strings.CutSuffix(name, ".rej"); found {
+		if i := strings.LastIndex(base, "."); i >= 0 && !strings.HasSuffix(base, ".plan") {
+			if _, err := strconv.Atoi(base[i+1:]); err == nil {
+				base = base[:i]
+			}
+		}
+		name = base
+	}
+	return strings.HasSuffix(name, ".plan")
+}
+
+// ErrChangedOnDisk is returned when saving a copy of a plan file that changed since the copy was made
+var ErrChangedOnDisk = errors.New("plan file changed on disk while it was being edited")
+
+// PlainCopy is a decrypted copy of an encrypted plan file, for editors that need a file
+type PlainCopy struct {
+	FilePath string // The plan file the copy belongs to (its plaintext name)
+	TempPath string // The decrypted copy in a private temporary directory
+
+	originalHash string
+	store        *Store
+}
+
+// StartPlainCopy decrypts a plan file into a private temporary directory
+// The copy keeps the plan file's name so editors recognize it
+func (s *Store) StartPlainCopy(filePath string) (*PlainCopy, error) {
+	content, err := s.readPlan(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	tempPath, err := writePrivateTemp(filepath.Base(filePath), content)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(content)
+	return &PlainCopy{FilePath: filePath, TempPath: tempPath, originalHash: hex.EncodeToString(sum[:]), store: s}, nil
+}
+
+// Save writes the edited copy back to the plan file, encrypting it
+// If the plan file changed since the copy was made (e.g. plan add or plan serve appended
+// to it), nothing is written and ErrChangedOnDisk is returned, so neither change is lost
+func (c *PlainCopy) Save() error {
+	unlock, err := c.store.lockDirectory(filepath.Dir(c.FilePath))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if hash, err := c.store.HashFile(c.FilePath); err != nil {
+		return err
+	} else if hash != c.originalHash {
+		return ErrChangedOnDisk
+	}
+
+	content, err := os.ReadFile(c.TempPath)
+	if err != nil {
+		return fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return c.store.writePlan(c.FilePath, content, 0600)
+}
+
+// Modified reports whether the copy differs from the plan file, e.g. to keep an edit
+// that couldn't be saved
+func (c *PlainCopy) Modified() (bool, error) {
+	edited, err := os.ReadFile(c.TempPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read edited file: %w", err)
+	}
+	original, err := c.store.readPlan(c.FilePath)
+	if err != nil {
+		return false, err
+	}
+	return !bytes.Equal(edited, original), nil
+}
+
+// Reject keeps the edited copy next to the plan file as a reject file, encrypted like
+// the plan file (see ScopedEdit.Reject). Returns the path of the reject file
+func (c *PlainCopy) Reject() (string, error) {
+	content, err := os.ReadFile(c.TempPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return c.store.writeReject(c.FilePath, content)
+}
+
+// Cleanup securely removes the decrypted copy and its directory
+func (c *PlainCopy) Cleanup() error {
+	return removePrivateTemp(c.TempPath)
+}
+
+// writePrivateTemp writes content to a new file in a temporary directory only the user can open
+func writePrivateTemp(name string, content []byte) (string, error) {
+	dir, err := os.MkdirTemp("", "plan-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	// MkdirTemp uses 0700 already; make sure of it in case of an unusual umask
+	if err := os.Chmod(dir, 0700); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("failed to secure temporary directory: %w", err)
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	return path, nil
+}
+
+// removePrivateTemp securely removes a file made by writePrivateTemp and its directory
+// Editors may leave swap or backup files next to it, so those are overwritten too
+func removePrivateTemp(path string) error {
+	dir := filepath.Dir(path)
+	entries, _ := os.ReadDir(dir)
+	var firstErr error
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if err := vault.SecureRemove(filepath.Join(dir, entry.Name())); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if err := os.RemoveAll(dir); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
@@ -0,0 +1,243 @@
+package planfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/vault"
+)
+
+// useFastKeys lowers the key derivation cost so encryption tests stay quick
+func useFastKeys(t *testing.T) {
+	t.Helper()
+	orig := vault.DefaultParams
+	vault.DefaultParams = vault.Params{LogN: 10, R: 8, P: 1}
+	t.Cleanup(func() {
+		vault.DefaultParams = orig
+	})
+}
+
+// encryptedTestStore creates a plans directory with one month and encrypts it
+func encryptedTestStore(t *testing.T, passphrase string) *Store {
+	t.Helper()
+	useFastKeys(t)
+	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
+	files := map[string]string{
+		"2026-02.plan":       scopedTestContent,
+		"2026-02.plan.rej":   "## 2026-02-13\n* Rejected edit\n",
+		"2026-02.plan.2.rej": "## 2026-02-13\n* Rejected again\n",
+		"notes.txt":          "not a plan file\n",
+		"notes.2.rej":        "not a plan file either\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	encrypted, err := store.EncryptDirectory(passphrase)
+	if err != nil {
+		t.Fatalf("EncryptDirectory() error = %v", err)
+	}
+	if strings.Join(encrypted, ",") != "2026-02.plan,2026-02.plan.2.rej,2026-02.plan.rej" {
+		t.Errorf("EncryptDirectory() encrypted %v, want the month and reject files", encrypted)
+	}
+	return store
+}
+
+func TestEncryptDirectory(t *testing.T) {
+	store := encryptedTestStore(t, "secret")
+	tmpDir := store.Dir
+
+	if !store.Encrypted() {
+		t.Fatal("Encrypted() = false after EncryptDirectory()")
+	}
+	for _, name := range []string{"2026-02.plan", "2026-02.plan.rej", "2026-02.plan.2.rej"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); !os.IsNotExist(err) {
+			t.Errorf("plain %s still exists", name)
+		}
+		data, err := os.ReadFile(filepath.Join(tmpDir, name+vault.Extension))
+		if err != nil {
+			t.Fatalf("encrypted %s missing: %v", name, err)
+		}
+		if !vault.IsEncrypted(data) || strings.Contains(string(data), "Entry") {
+			t.Errorf("%s%s is not encrypted", name, vault.Extension)
+		}
+	}
+	for _, name := range []string{"notes.txt", "notes.2.rej"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); err != nil {
+			t.Errorf("non-plan file %s was touched: %v", name, err)
+		}
+	}
+
+	// Encrypting again with the same passphrase resumes; a different one is refused
+	if _, err := store.EncryptDirectory("secret"); err != nil {
+		t.Errorf("EncryptDirectory() again error = %v", err)
+	}
+	if _, err := store.EncryptDirectory("other"); err == nil {
+		t.Error("EncryptDirectory() with a different passphrase succeeded")
+	}
+}
+
+func TestEncryptedJournalReadWrite(t *testing.T) {
+	store := encryptedTestStore(t, "secret")
+	tmpDir := store.Dir
+	prompts := 0
+	store = &Store{Dir: tmpDir, Passphrase: func() (string, error) {
+		prompts++
+		return "secret", nil
+	}}
+
+	content, err := store.ReadEntries("2026-02-13")
+	if err != nil || !strings.Contains(content, "Entry 1") {
+		t.Fatalf("ReadEntries() = %q, %v", content, err)
+	}
+
+	days, err := store.DiscoverDays("")
+	if err != nil || len(days) != 2 {
+		t.Fatalf("DiscoverDays() = %v, %v, want 2 days", days, err)
+	}
+
+	// Writes stay encrypted
+	date := time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC)
+	if _, err := store.EnsureDateHeader(date); err != nil {
+		t.Fatalf("EnsureDateHeader() error = %v", err)
+	}
+	filePath := filepath.Join(tmpDir, "2026-02.plan")
+	if got := store.StoredPath(filePath); got != filePath+vault.Extension {
+		t.Errorf("StoredPath() = %q, want the .enc file", got)
+	}
+	stored, _ := os.ReadFile(store.StoredPath(filePath))
+	if strings.Contains(string(stored), "2026-02-20") {
+		t.Error("EnsureDateHeader() wrote plaintext")
+	}
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		t.Error("EnsureDateHeader() created a plain file")
+	}
+	if line, _ := store.FindDateSectionLine(filePath, "2026-02-20"); line == 0 {
+		t.Error("added date header not found after re-reading")
+	}
+
+	files, err := store.CompleteFiles(days, "")
+	if err != nil || len(files) != 1 || files[0].Value != "2026-02.plan" {
+		t.Errorf("CompleteFiles() = %v, %v, want the plaintext month name", files, err)
+	}
+
+	if prompts != 1 {
+		t.Errorf("passphrase asked %d times, want once", prompts)
+	}
+}
+
+func TestEncryptedJournalLocked(t *testing.T) {
+	store := encryptedTestStore(t, "secret")
+
+	// The keyring unlocked by EncryptDirectory belongs to that Store only
+	store = &Store{Dir: store.Dir}
+	if _, err := store.DiscoverDays(""); !errors.Is(err, ErrNoPassphrase) {
+		t.Errorf("DiscoverDays() without a passphrase error = %v, want ErrNoPassphrase", err)
+	}
+
+	store = &Store{Dir: store.Dir, Passphrase: func() (string, error) { return "wrong", nil }}
+	if _, err := store.DiscoverDates(""); !IsUnlockError(err) {
+		t.Errorf("DiscoverDates() with a wrong passphrase error = %v, want an unlock error", err)
+	}
+	if _, err := store.ReadEntries("2026-02"); err == nil {
+		t.Error("ReadEntries() with a wrong passphrase succeeded")
+	}
+}
+
+func TestDecryptDirectory(t *testing.T) {
+	store := encryptedTestStore(t, "secret")
+	tmpDir := store.Dir
+
+	if _, err := store.DecryptDirectory("wrong"); !errors.Is(err, vault.ErrWrongPassphrase) {
+		t.Fatalf("DecryptDirectory() error = %v, want ErrWrongPassphrase", err)
+	}
+
+	decrypted, err := store.DecryptDirectory("secret")
+	if err != nil {
+		t.Fatalf("DecryptDirectory() error = %v", err)
+	}
+	if len(decrypted) != 3 || store.Encrypted() {
+		t.Errorf("DecryptDirectory() = %v, encrypted = %v", decrypted, store.Encrypted())
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "2026-02.plan"))
+	if err != nil || string(content) != scopedTestContent {
+		t.Errorf("decrypted content = %q, %v, want the original", content, err)
+	}
+	if _, err := store.DecryptDirectory("secret"); err == nil {
+		t.Error("DecryptDirectory() on a plain journal succeeded")
+	}
+}
+
+func TestPlainCopy(t *testing.T) {
+	store := encryptedTestStore(t, "secret")
+	tmpDir := store.Dir
+	store = &Store{Dir: tmpDir, Passphrase: func() (string, error) { return "secret", nil }}
+	filePath := filepath.Join(tmpDir, "2026-02.plan")
+
+	plain, err := store.StartPlainCopy(filePath)
+	if err != nil {
+		t.Fatalf("StartPlainCopy() error = %v", err)
+	}
+	if filepath.Base(plain.TempPath) != "2026-02.plan" || strings.HasPrefix(plain.TempPath, tmpDir) {
+		t.Errorf("TempPath = %q, want 2026-02.plan outside the plans directory", plain.TempPath)
+	}
+	if info, err := os.Stat(filepath.Dir(plain.TempPath)); err != nil || info.Mode().Perm() != 0700 {
+		t.Errorf("temporary directory is not private: %v", err)
+	}
+
+	if modified, err := plain.Modified(); err != nil || modified {
+		t.Errorf("Modified() before editing = %v, %v", modified, err)
+	}
+	edited := scopedTestContent + "\n## 2026-02-15\n* Written in the editor\n"
+	if err := os.WriteFile(plain.TempPath, []byte(edited), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if modified, err := plain.Modified(); err != nil || !modified {
+		t.Errorf("Modified() after editing = %v, %v", modified, err)
+	}
+	if err := plain.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := plain.Cleanup(); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Dir(plain.TempPath)); !os.IsNotExist(err) {
+		t.Error("Cleanup() left the temporary directory")
+	}
+
+	content, err := store.ReadEntries("2026-02-15")
+	if err != nil || !strings.Contains(content, "Written in the editor") {
+		t.Errorf("ReadEntries() after Save() = %q, %v", content, err)
+	}
+
+	// An append made while the copy is edited isn't overwritten; the edit is rejected
+	plain, err = store.StartPlainCopy(filePath)
+	if err != nil {
+		t.Fatalf("StartPlainCopy() error = %v", err)
+	}
+	defer plain.Cleanup()
+	if err := os.WriteFile(plain.TempPath, []byte(edited+"* Edited again\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.AppendLines(time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC), "", []string{"* Appended meanwhile"}); err != nil {
+		t.Fatalf("AppendLines() error = %v", err)
+	}
+	if err := plain.Save(); !errors.Is(err, ErrChangedOnDisk) {
+		t.Fatalf("Save() after a change on disk error = %v, want ErrChangedOnDisk", err)
+	}
+	rejPath, err := plain.Reject()
+	if err != nil || rejPath != filePath+".3.rej"+vault.Extension {
+		t.Errorf("Reject() = %q, %v, want the next free reject file", rejPath, err)
+	}
+	if content, _ := store.ReadEntries("2026-02-15"); !strings.Contains(content, "Appended meanwhile") || strings.Contains(content, "Edited again") {
+		t.Errorf("ReadEntries() after a rejected Save() = %q", content)
+	}
+}
@@ -0,0 +1,154 @@
+package planfile
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+)
+
+// DaySummary describes a date section for listings and statistics
+// Summaries are stored in the index (see IndexFileName), so changing the fields
+// means bumping indexVersion
+type DaySummary struct {
+	Date      string   `json:"date"`            // YYYY-MM-DD
+	Title     string   `json:"title,omitempty"` // Text after the date in the header, if any
+	Lines     int      `json:"lines"`           // Non-empty content lines
+	Words     int      `json:"words"`
+	OpenTasks int      `json:"open,omitempty"` // "- [ ]" items
+	DoneTasks int      `json:"done,omitempty"` // "- [x]" items
+	Tags      []string `json:"tags,omitempty"` // #tags in the header and content, without "#", in order of first use
+}
+
+// tagRegex matches a #tag at the start of a line or after a space or parenthesis
+var tagRegex = regexp.MustCompile(`(?:^|[\s(])#([A-Za-z][\w/-]*)`)
+
+// SummarizeDay counts lines, words, tasks and tags in a date section
+func SummarizeDay(date, header string, content []string) DaySummary {
+	summary := DaySummary{Date: date, Title: HeaderTitle(date, header)}
+	summary.addTags(summary.Title)
+	for _, line := range content {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		summary.Lines++
+		summary.Words += countWords(line)
+		summary.addTags(line)
+
+		switch TaskState(line) {
+		case TaskOpen:
+			summary.OpenTasks++
+		case TaskDone:
+			summary.DoneTasks++
+		}
+	}
+	return summary
+}
+
+// addTags records the #tags in text that the summary doesn't have yet
+func (s *DaySummary) addTags(text string) {
+	// Most lines have no tags; skip the regexp for them
+	if !strings.Contains(text, "#") {
+		return
+	}
+	for _, match := range tagRegex.FindAllStringSubmatch(text, -1) {
+		if !slices.Contains(s.Tags, match[1]) {
+			s.Tags = append(s.Tags, match[1])
+		}
+	}
+}
+
+// countWords counts words in a line, ignoring bullets, task boxes and other punctuation
+func countWords(line string) int {
+	count := 0
+	for _, field := range strings.Fields(line) {
+		if field == "[x]" || field == "[X]" {
+			continue
+		}
+		if strings.IndexFunc(field, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// Task states returned by TaskState
+const (
+	TaskNone = iota
+	TaskOpen
+	TaskDone
+)
+
+// TaskState reports whether a line is an open or done task ("- [ ]", "* [x]", "+ [X]")
+func TaskState(line string) int {
+	trimmed := strings.TrimLeft(line, " \t")
+	if len(trimmed) < 5 || !strings.ContainsRune("-*+", rune(trimmed[0])) || trimmed[1] != ' ' {
+		return TaskNone
+	}
+	switch trimmed[2:5] {
+	case "[ ]":
+		return TaskOpen
+	case "[x]", "[X]":
+		return TaskDone
+	}
+	return TaskNone
+}
+
+// HeaderTitle returns the text after the date in a "## YYYY-MM-DD - Title" header
+func HeaderTitle(date, header string) string {
+	title := strings.TrimPrefix(strings.TrimPrefix(header, "## "), date)
+	return strings.TrimSpace(strings.TrimLeft(title, " \t-–—:|"))
+}
+
+// DiscoverDays returns a summary of every date in the plan files, oldest first
+// Files that haven't changed since they were indexed aren't parsed again
+// filter can be empty (all dates), YYYY (specific year), or YYYY-MM (specific month)
+func (s *Store) DiscoverDays(filter string) ([]DaySummary, error) {
+	if filter != "" {
+		if _, _, err := dateutil.FilterRange(filter); err != nil {
+			return nil, err
+		}
+	}
+
+	indexed, err := s.indexedDays(filter, false)
+	if err != nil {
+		if IsUnlockError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read plans directory: %w", err)
+	}
+
+	var days []DaySummary
+	for _, day := range indexed {
+		if filter == "" || strings.HasPrefix(day.Date, filter+"-") {
+			days = append(days, day)
+		}
+	}
+
+	sort.SliceStable(days, func(i, j int) bool {
+		return days[i].Date < days[j].Date
+	})
+	return days, nil
+}
+
+// MissingWeekdays returns the weekdays between from and to (inclusive) without an entry
+func MissingWeekdays(days []DaySummary, from, to time.Time) []string {
+	logged := make(map[string]bool, len(days))
+	for _, day := range days {
+		logged[day.Date] = true
+	}
+
+	var missing []string
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		dateStr := dateutil.FormatDate(d)
+		if dateutil.IsWeekday(d) && !logged[dateStr] {
+			missing = append(missing, dateStr)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,118 @@
+package planfile
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSummarizeDay(t *testing.T) {
+	content := []string{
+		"* Wrote the report",
+		"",
+		"- [ ] Call the bank",
+		"  * [x] Nested done task",
+		"- [X] Done too",
+		"- [] not a task",
+	}
+
+	got := SummarizeDay("2026-02-13", "## 2026-02-13 - Planning day", content)
+	want := DaySummary{
+		Date:      "2026-02-13",
+		Title:     "Planning day",
+		Lines:     5,
+		Words:     14,
+		OpenTasks: 1,
+		DoneTasks: 2,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeDay() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSummarizeDayTags(t *testing.T) {
+	content := []string{
+		"#standup notes",
+		"* Reviewed the plan (#work/q3) with #team",
+		"* Issue #42 and https://example.com/#anchor aren't tags",
+		"* More #team",
+	}
+
+	got := SummarizeDay("2026-02-13", "## 2026-02-13 - Offsite #travel", content).Tags
+	want := []string{"travel", "standup", "work/q3", "team"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeDay() tags = %v, want %v", got, want)
+	}
+}
+
+func TestHeaderTitle(t *testing.T) {
+	tests := map[string]string{
+		"## 2026-02-13":               "",
+		"## 2026-02-13 - Planning":    "Planning",
+		"## 2026-02-13: Retro notes":  "Retro notes",
+		"## 2026-02-13 — Offsite":     "Offsite",
+		"## 2026-02-13 Release day  ": "Release day",
+	}
+
+	for header, want := range tests {
+		if got := HeaderTitle("2026-02-13", header); got != want {
+			t.Errorf("HeaderTitle(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestDiscoverDays(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := &Store{Dir: tmpDir}
+	files := map[string]string{
+		"2026-01.plan": "# 2026-01\n\n## 2026-01-20\n* Late\n\n## 2026-01-05 - First\n* One two\n",
+		"2026-02.plan": "# 2026-02\n\n## 2026-02-02\n- [ ] Open\n",
+		"notes.plan":   "# notes\n\n## 2026-03-01\n* Ignored\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	days, err := store.DiscoverDays("")
+	if err != nil {
+		t.Fatalf("DiscoverDays() error = %v", err)
+	}
+	var dates []string
+	for _, day := range days {
+		dates = append(dates, day.Date)
+	}
+	if want := []string{"2026-01-05", "2026-01-20", "2026-02-02"}; !reflect.DeepEqual(dates, want) {
+		t.Errorf("DiscoverDays() dates = %v, want %v", dates, want)
+	}
+	if days[0].Title != "First" || days[0].Words != 2 {
+		t.Errorf("DiscoverDays() first day = %+v", days[0])
+	}
+	if days[2].OpenTasks != 1 {
+		t.Errorf("DiscoverDays() open tasks = %d, want 1", days[2].OpenTasks)
+	}
+
+	filtered, err := store.DiscoverDays("2026-02")
+	if err != nil || len(filtered) != 1 {
+		t.Errorf("DiscoverDays() = %v, %v, want 1 day", filtered, err)
+	}
+
+	if _, err := store.DiscoverDays("26"); err == nil {
+		t.Error("DiscoverDays() expected error for invalid filter")
+	}
+}
+
+func TestMissingWeekdays(t *testing.T) {
+	days := []DaySummary{{Date: "2026-02-09"}, {Date: "2026-02-11"}, {Date: "2026-02-14"}}
+	from := time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC)
+
+	got := MissingWeekdays(days, from, to)
+	want := []string{"2026-02-10", "2026-02-12", "2026-02-13", "2026-02-16"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MissingWeekdays() = %v, want %v", got, want)
+	}
+}
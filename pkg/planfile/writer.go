@@ -1,7 +1,6 @@
 package planfile
 
 import (
-	"os"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -58,10 +57,10 @@ func GenerateFileContent(pf *PlanFile) string {
 }
 
 // WritePlanFile writes a PlanFile structure to disk
-func WritePlanFile(filePath string, pf *PlanFile) error {
+func (s *Store) WritePlanFile(filePath string, pf *PlanFile) error {
 	// Ensure directory exists
 	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := s.mkdirAll(dir, 0755); err != nil {
 		return err
 	}
 
@@ -69,12 +68,12 @@ func WritePlanFile(filePath string, pf *PlanFile) error {
 	content := GenerateFileContent(pf)
 
 	// Write to file
-	return os.WriteFile(filePath, []byte(content), 0644)
+	return s.writePlan(filePath, []byte(content), 0644)
 }
 
-// EnsureDirectory ensures a directory exists
-func EnsureDirectory(dir string) error {
-	return os.MkdirAll(dir, 0755)
+// EnsureDirectory ensures the plans directory exists
+func (s *Store) EnsureDirectory() error {
+	return s.mkdirAll(s.Dir, 0755)
 }
 
 // trimTrailingEmptyLines removes trailing empty lines from a slice of strings
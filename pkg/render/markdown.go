@@ -0,0 +1,328 @@
+package render
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/output"
+)
+
+// Options control how plan content is rendered
+type Options struct {
+	Width int       // Wrap lines to this many columns; 0 disables wrapping
+	Today time.Time // Date headers are colored relative to today, like plan list
+}
+
+var (
+	// dateHeaderRegex matches "## YYYY-MM-DD" with an optional title
+	dateHeaderRegex = regexp.MustCompile(`^## (\d{4}-\d{2}-\d{2})(.*)$`)
+	// headingRegex matches any other Markdown heading, including the month header
+	headingRegex = regexp.MustCompile(`^#{1,6}\s`)
+	// listItemRegex matches a bullet or numbered list item: indent, marker, text
+	listItemRegex = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	// taskRegex matches a task marker at the start of a list item's text
+	taskRegex = regexp.MustCompile(`^\[([ xX])\]\s+`)
+	// quoteRegex matches a block quote prefix
+	quoteRegex = regexp.MustCompile(`^(\s*>\s?)(.*)$`)
+)
+
+// Markdown renders plan content for the terminal: date headers colored by past, today,
+// and future; bold, italic, code, link, and #tag spans; task markers colored by status; and
+// list items wrapped with their continuation lines indented under the text
+// With colors disabled, inline markup is kept as written and only wrapping applies
+func Markdown(content string, opts Options) string {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+
+	var rendered []string
+	inCode := false
+	for _, line := range lines {
+		// Fenced code blocks are shown verbatim
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+			rendered = append(rendered, output.Code(line))
+			continue
+		}
+		if inCode {
+			rendered = append(rendered, output.Code(line))
+			continue
+		}
+
+		rendered = append(rendered, renderLine(line, opts)...)
+	}
+	return strings.Join(rendered, "\n")
+}
+
+// renderLine renders one source line, which may wrap onto several output lines
+func renderLine(line string, opts Options) []string {
+	if strings.TrimSpace(line) == "" {
+		return []string{""}
+	}
+
+	if match := dateHeaderRegex.FindStringSubmatch(line); match != nil {
+		return []string{output.Header("## ") + output.Header(output.FormatDate(match[1], opts.Today)) + output.Header(match[2])}
+	}
+	if headingRegex.MatchString(line) {
+		return []string{output.Header(line)}
+	}
+
+	if match := listItemRegex.FindStringSubmatch(line); match != nil {
+		indent, marker, text := match[1], match[2], match[3]
+		prefix := indent + marker + " "
+		styledPrefix := prefix
+
+		if task := taskRegex.FindStringSubmatch(text); task != nil {
+			box := "[" + task[1] + "]"
+			text = text[len(task[0]):]
+			prefix += box + " "
+			if task[1] == " " {
+				styledPrefix += output.TaskOpen(box) + " "
+			} else {
+				styledPrefix += output.TaskDone(box) + " "
+			}
+		}
+		return wrapSpans(parseInline(text), styledPrefix, strings.Repeat(" ", visibleWidth(prefix)), opts.Width)
+	}
+
+	if match := quoteRegex.FindStringSubmatch(line); match != nil {
+		prefix := output.Info(match[1])
+		return wrapSpans(parseInline(match[2]), prefix, prefix, opts.Width)
+	}
+
+	// Paragraph text keeps its own indentation on every wrapped line
+	text := strings.TrimLeft(line, " \t")
+	indent := strings.ReplaceAll(line[:len(line)-len(text)], "\t", "    ")
+	return wrapSpans(parseInline(text), indent, indent, opts.Width)
+}
+
+// style is the inline formatting of a span of text
+type style int
+
+const (
+	stylePlain style = iota
+	styleBold
+	styleItalic
+	styleCode
+	styleLink
+	styleTag
+)
+
+// span is a run of text with a single style
+type span struct {
+	text  string
+	style style
+}
+
+// apply formats text in a style
+func (s style) apply(text string) string {
+	switch s {
+	case styleBold:
+		return output.Bold(text)
+	case styleItalic:
+		return output.Italic(text)
+	case styleCode:
+		return output.Code(text)
+	case styleLink:
+		return output.Link(text)
+	case styleTag:
+		return output.Tag(text)
+	}
+	return text
+}
+
+// parseInline splits text into styled spans, removing the Markdown markup
+func parseInline(text string) []span {
+	if output.ColorsDisabled() {
+		return []span{{text, stylePlain}}
+	}
+
+	var spans []span
+	var plain strings.Builder
+	flush := func() {
+		if plain.Len() > 0 {
+			spans = append(spans, span{plain.String(), stylePlain})
+			plain.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		inner, length, st := matchInline(text, i)
+		if length > 0 {
+			flush()
+			spans = append(spans, span{inner, st})
+			// Keep the target of [text](url) links visible after the text
+			if url, found := strings.CutPrefix(rest[:length], "["+inner+"]("); found {
+				spans = append(spans, span{" (" + strings.TrimSuffix(url, ")") + ")", stylePlain})
+			}
+			i += length
+			continue
+		}
+		plain.WriteByte(text[i])
+		i++
+	}
+	flush()
+	return spans
+}
+
+// matchInline looks for an inline span starting at text[i]
+// Returns the span's visible text, the length of its markup in text, and its style
+func matchInline(text string, i int) (string, int, style) {
+	rest := text[i:]
+	switch {
+	case strings.HasPrefix(rest, "`"):
+		if end := strings.Index(rest[1:], "`"); end > 0 {
+			return rest[1 : end+1], end + 2, styleCode
+		}
+	case strings.HasPrefix(rest, "**"), strings.HasPrefix(rest, "__"):
+		if inner, ok := delimited(text, i, rest[:2]); ok {
+			return inner, len(inner) + 4, styleBold
+		}
+	case strings.HasPrefix(rest, "*"), strings.HasPrefix(rest, "_"):
+		if inner, ok := delimited(text, i, rest[:1]); ok {
+			return inner, len(inner) + 2, styleItalic
+		}
+	case strings.HasPrefix(rest, "["):
+		if match := linkRegex.FindStringSubmatch(rest); match != nil {
+			return match[1], len(match[0]), styleLink
+		}
+	case strings.HasPrefix(rest, "http://"), strings.HasPrefix(rest, "https://"):
+		if i == 0 || !isWordByte(text[i-1]) {
+			url := urlRegex.FindString(rest)
+			return url, len(url), styleLink
+		}
+	case strings.HasPrefix(rest, "#"):
+		if i == 0 || text[i-1] == ' ' || text[i-1] == '(' {
+			if tag := tagRegex.FindString(rest); tag != "" {
+				return tag, len(tag), styleTag
+			}
+		}
+	}
+	return "", 0, stylePlain
+}
+
+var (
+	// linkRegex matches [text](url) at the start of a string
+	linkRegex = regexp.MustCompile(`^\[([^\]]+)\]\(([^)\s]+)\)`)
+	// urlRegex matches a bare URL, leaving trailing punctuation outside it
+	urlRegex = regexp.MustCompile(`^https?://[^\s<>()]*[^\s<>().,;:!?'"]`)
+	// tagRegex matches a #tag at the start of a string
+	tagRegex = regexp.MustCompile(`^#[A-Za-z][\w/-]*`)
+)
+
+// delimited returns the text between a delimiter at text[i] and its closing match
+// Emphasis must hug its text ("*a*", not "* a *"), and underscores inside words
+// (snake_case) don't count
+func delimited(text string, i int, delim string) (string, bool) {
+	if delim[0] == '_' && i > 0 && isWordByte(text[i-1]) {
+		return "", false
+	}
+
+	start := i + len(delim)
+	if start >= len(text) || text[start] == ' ' {
+		return "", false
+	}
+	end := strings.Index(text[start:], delim)
+	if end <= 0 || text[start+end-1] == ' ' {
+		return "", false
+	}
+
+	after := start + end + len(delim)
+	if delim[0] == '_' && after < len(text) && isWordByte(text[after]) {
+		return "", false
+	}
+	return text[start : start+end], true
+}
+
+// isWordByte reports whether b is an ASCII letter or digit
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+// word is a piece of a line for wrapping: a run of non-space text or a run of spaces
+type word struct {
+	text  string
+	style style
+	space bool
+}
+
+// wrapSpans lays out spans after prefix, wrapping at width with later lines
+// starting with continuation; words longer than a line are not broken
+func wrapSpans(spans []span, prefix, continuation string, width int) []string {
+	var words []word
+	for _, s := range spans {
+		for _, piece := range splitSpaces(s.text) {
+			words = append(words, word{piece, s.style, strings.TrimSpace(piece) == ""})
+		}
+	}
+
+	available := width - visibleWidth(continuation)
+	var lines []string
+	var current []word
+	currentWidth := 0
+	for _, w := range words {
+		wWidth := visibleWidth(w.text)
+		if width > 0 && !w.space && currentWidth+wWidth > available && currentWidth > 0 {
+			lines = append(lines, renderWords(current))
+			current, currentWidth = nil, 0
+		}
+		if w.space && currentWidth == 0 && len(lines) > 0 {
+			continue // Don't start a wrapped line with spaces
+		}
+		current = append(current, w)
+		currentWidth += wWidth
+	}
+	lines = append(lines, renderWords(current))
+
+	for i := range lines {
+		if i == 0 {
+			lines[i] = prefix + lines[i]
+		} else {
+			lines[i] = continuation + lines[i]
+		}
+	}
+	return lines
+}
+
+// renderWords joins words into a line, styling runs that share a style together
+func renderWords(words []word) string {
+	// Trailing spaces are dropped where a line wraps
+	for len(words) > 0 && words[len(words)-1].space {
+		words = words[:len(words)-1]
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(words); {
+		j := i
+		var run strings.Builder
+		for j < len(words) && words[j].style == words[i].style {
+			run.WriteString(words[j].text)
+			j++
+		}
+		b.WriteString(words[i].style.apply(run.String()))
+		i = j
+	}
+	return b.String()
+}
+
+// splitSpaces splits text into alternating runs of spaces and non-spaces
+func splitSpaces(text string) []string {
+	var pieces []string
+	start := 0
+	for i := 1; i <= len(text); i++ {
+		if i == len(text) || (text[i] == ' ') != (text[i-1] == ' ') {
+			pieces = append(pieces, text[start:i])
+			start = i
+		}
+	}
+	return pieces
+}
+
+// ansiEscape matches SGR color sequences
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// visibleWidth returns the number of characters shown for text, not counting color
+// codes (e.g. in a styled block quote prefix)
+func visibleWidth(text string) int {
+	return len([]rune(ansiEscape.ReplaceAllString(text, "")))
+}
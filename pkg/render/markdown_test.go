@@ -0,0 +1,121 @@
+package render
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/output"
+)
+
+var today = time.Date(2026, 2, 13, 9, 0, 0, 0, time.Local)
+
+func withColors(t *testing.T, enabled bool) {
+	t.Helper()
+	output.SetColorsDisabled(!enabled)
+	t.Cleanup(func() { output.SetColorsDisabled(false) })
+}
+
+func TestMarkdownHeaders(t *testing.T) {
+	withColors(t, true)
+
+	got := Markdown("# 2026-02\n\n## 2026-02-13 - Offsite\n## 2026-02-12\n### Notes", Options{Today: today})
+	want := strings.Join([]string{
+		output.Header("# 2026-02"),
+		"",
+		output.Header("## ") + output.Header(output.DateGreen("2026-02-13")) + output.Header(" - Offsite"),
+		output.Header("## ") + output.Header("2026-02-12") + output.Header(""),
+		output.Header("### Notes"),
+	}, "\n")
+	if got != want {
+		t.Errorf("Markdown() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestMarkdownInline(t *testing.T) {
+	withColors(t, true)
+
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"bold", "a **big** deal", "a " + output.Bold("big") + " deal"},
+		{"italic", "an *odd* _one_", "an " + output.Italic("odd") + " " + output.Italic("one")},
+		{"code", "run `go test` now", "run " + output.Code("go test") + " now"},
+		{"link", "see [docs](https://example.com).", "see " + output.Link("docs") + " (https://example.com)."},
+		{"bare url", "at https://example.com/x.", "at " + output.Link("https://example.com/x") + "."},
+		{"snake_case is not italic", "my_var_name", "my_var_name"},
+		{"loose stars are not italic", "2 * 3 * 4", "2 * 3 * 4"},
+		{"unclosed code", "a `b", "a `b"},
+		{"tags", "#work and (#team/ops)", output.Tag("#work") + " and (" + output.Tag("#team/ops") + ")"},
+		{"not a tag", "issue#12 and # 3", "issue#12 and # 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Markdown(tt.line, Options{Today: today}); got != tt.want {
+				t.Errorf("Markdown(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownTasks(t *testing.T) {
+	withColors(t, true)
+
+	got := Markdown("- [ ] call\n  * [x] done", Options{Today: today})
+	want := "- " + output.TaskOpen("[ ]") + " call\n  * " + output.TaskDone("[x]") + " done"
+	if got != want {
+		t.Errorf("Markdown() = %q, want %q", got, want)
+	}
+}
+
+func TestMarkdownWrapping(t *testing.T) {
+	withColors(t, false)
+
+	content := strings.Join([]string{
+		"- [ ] a task with quite a few words in it",
+		"  1. nested numbered item that wraps",
+		"> quoted text that wraps too",
+		"plain paragraph text here",
+		"```",
+		"code lines are never wrapped at all",
+		"```",
+	}, "\n")
+	got := Markdown(content, Options{Width: 20, Today: today})
+	want := strings.Join([]string{
+		"- [ ] a task with",
+		"      quite a few",
+		"      words in it",
+		"  1. nested numbered",
+		"     item that wraps",
+		"> quoted text that",
+		"> wraps too",
+		"plain paragraph text",
+		"here",
+		"```",
+		"code lines are never wrapped at all",
+		"```",
+	}, "\n")
+	if got != want {
+		t.Errorf("Markdown() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Color codes in a block quote prefix don't count towards the width
+	withColors(t, true)
+	info := output.Info
+	output.Info = func(s string) string { return "\x1b[36m" + s + "\x1b[0m" }
+	defer func() { output.Info = info }()
+	got = Markdown("> quoted text that wraps too", Options{Width: 20, Today: today})
+	want = output.Info("> ") + "quoted text that\n" + output.Info("> ") + "wraps too"
+	if got != want {
+		t.Errorf("Markdown() with colors =\n%q\nwant\n%q", got, want)
+	}
+	withColors(t, false)
+
+	// Markup is kept as written without colors
+	if got := Markdown("a **b** [c](d)", Options{Today: today}); got != "a **b** [c](d)" {
+		t.Errorf("Markdown() without colors = %q", got)
+	}
+}
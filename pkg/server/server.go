@@ -0,0 +1,332 @@
+// Package server serves a journal over HTTP: a JSON API under /api/ and a small web UI
+// for reading and adding entries from a browser
+//
+// Changes go through the journal package, so they take the same plans directory lock
+// and atomic writes as the plan command and can run alongside it
+package server
+
+import (
+	"crypto/subtle"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/abyss/plan-journal-cli/pkg/dateutil"
+	"github.com/abyss/plan-journal-cli/pkg/journal"
+	"github.com/abyss/plan-journal-cli/pkg/planfile"
+)
+
+// maxBodySize limits the size of request bodies
+const maxBodySize = 1 << 20
+
+// page is the web UI, a single page that uses the API
+//
+//go:embed ui.html
+var page []byte
+
+// Options configure a Server
+type Options struct {
+	// Token, if set, must be sent as "Authorization: Bearer <token>" with API requests
+	Token string
+	// ReadOnly rejects requests that change the journal
+	ReadOnly bool
+	// Changed is called after a request changes a month file, e.g. to commit it
+	Changed func(Change)
+	// Hosts are the names the server may be reached by, besides localhost and IP
+	// addresses. Requests for other names are rejected (see allowedHost)
+	Hosts []string
+}
+
+// Change describes a month file changed through the API
+type Change struct {
+	File  string // The plaintext path of the month file
+	Date  string // The day appended to (YYYY-MM-DD), or the month formatted (YYYY-MM)
+	Lines int    // Lines appended; zero for formatting
+}
+
+// Server is an http.Handler for a journal
+type Server struct {
+	j    *journal.Journal
+	opts Options
+	mux  *http.ServeMux
+}
+
+// New returns a Server for a journal
+func New(j *journal.Journal, opts Options) *Server {
+	s := &Server{j: j, opts: opts, mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("GET /{$}", s.page)
+	s.mux.HandleFunc("GET /api/info", s.api(s.info))
+	s.mux.HandleFunc("GET /api/months", s.api(s.months))
+	s.mux.HandleFunc("GET /api/days", s.api(s.days))
+	s.mux.HandleFunc("GET /api/days/{date}", s.api(s.day))
+	s.mux.HandleFunc("POST /api/days/{date}", s.api(s.write(s.appendDay)))
+	s.mux.HandleFunc("GET /api/search", s.api(s.search))
+	s.mux.HandleFunc("POST /api/format/{month}", s.api(s.write(s.format)))
+	s.mux.HandleFunc("/api/", s.api(func(r *http.Request) (any, error) {
+		return nil, httpError(http.StatusNotFound, "no such endpoint: %s %s", r.Method, r.URL.Path)
+	}))
+	return s
+}
+
+// ServeHTTP routes a request to the UI or the API
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.allowedHost(r) {
+		writeJSON(w, http.StatusMisdirectedRequest, map[string]string{"error": fmt.Sprintf("unknown host: %s", r.Host)})
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// allowedHost reports whether a request is addressed to an IP address, localhost, or
+// one of Options.Hosts. Without this check, a web page could point its own domain at
+// this server (DNS rebinding) and read or change the journal through a visitor's browser
+func (s *Server) allowedHost(r *http.Request) bool {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.Trim(host, "[]"), ".")
+	if net.ParseIP(host) != nil || strings.EqualFold(host, "localhost") {
+		return true
+	}
+	for _, allowed := range s.opts.Hosts {
+		if strings.EqualFold(host, strings.TrimSuffix(allowed, ".")) {
+			return true
+		}
+	}
+	return false
+}
+
+// page serves the web UI, which needs no token: it holds no journal data itself
+func (s *Server) page(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'self'; script-src 'unsafe-inline'; style-src 'unsafe-inline'")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Write(page)
+}
+
+// statusError is an error with the HTTP status it is reported with
+type statusError struct {
+	status int
+	msg    string
+}
+
+func (e *statusError) Error() string {
+	return e.msg
+}
+
+// httpError returns an error reported with the given HTTP status
+func httpError(status int, format string, args ...any) error {
+	return &statusError{status: status, msg: fmt.Sprintf(format, args...)}
+}
+
+// api wraps an API handler: it checks the token and writes the result or error as JSON
+func (s *Server) api(handler func(r *http.Request) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		if !s.authorized(r) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="plan"`)
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "missing or wrong token"})
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		result, err := handler(r)
+		if err != nil {
+			writeJSON(w, errorStatus(err), map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+// write wraps a handler that changes the journal
+// Only JSON bodies are accepted: browsers can't send them to another site without its
+// permission (a CORS preflight this server never grants), which keeps web pages from
+// changing the journal through a visitor's browser
+func (s *Server) write(handler func(r *http.Request) (any, error)) func(r *http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		if s.opts.ReadOnly {
+			return nil, httpError(http.StatusForbidden, "the journal is served read-only")
+		}
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+			return nil, httpError(http.StatusUnsupportedMediaType, "request body must be application/json")
+		}
+		return handler(r)
+	}
+}
+
+// authorized reports whether a request carries the token, if one is required
+func (s *Server) authorized(r *http.Request) bool {
+	if s.opts.Token == "" {
+		return true
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.opts.Token)) == 1
+}
+
+// errorStatus maps an error to the HTTP status it is reported with
+func errorStatus(err error) int {
+	var statusErr *statusError
+	switch {
+	case errors.As(err, &statusErr):
+		return statusErr.status
+	case errors.Is(err, journal.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, journal.ErrInvalidEntry):
+		return http.StatusBadRequest
+	case errors.Is(err, journal.ErrLocked):
+		return http.StatusLocked
+	case errors.Is(err, planfile.ErrReadOnly):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// parseDay parses a day in a request path: YYYY-MM-DD, today, yesterday, or tomorrow
+func parseDay(target string) (time.Time, error) {
+	if dateutil.IsValidMonth(target) {
+		return time.Time{}, httpError(http.StatusBadRequest, "expected a day, not a month: %s", target)
+	}
+	date, err := dateutil.ParseTarget(target)
+	if err != nil {
+		return time.Time{}, httpError(http.StatusBadRequest, "%v", err)
+	}
+	return date, nil
+}
+
+// info describes the server, so clients know what they can do
+func (s *Server) info(r *http.Request) (any, error) {
+	return map[string]any{
+		"today":     dateutil.FormatDate(time.Now()),
+		"read_only": s.opts.ReadOnly,
+		"encrypted": s.j.Encrypted(),
+	}, nil
+}
+
+// months lists the months that have a plan file
+func (s *Server) months(r *http.Request) (any, error) {
+	months, err := s.j.Months()
+	if err != nil {
+		return nil, err
+	}
+	if months == nil {
+		months = []string{}
+	}
+	return map[string][]string{"months": months}, nil
+}
+
+// days lists day summaries, optionally filtered by ?filter=YYYY[-MM[-DD]]
+func (s *Server) days(r *http.Request) (any, error) {
+	filter := r.URL.Query().Get("filter")
+	if filter != "" {
+		if _, _, err := dateutil.FilterRange(filter); err != nil {
+			return nil, httpError(http.StatusBadRequest, "%v", err)
+		}
+	}
+
+	summaries, err := s.j.Summaries(filter)
+	if err != nil {
+		return nil, err
+	}
+	if summaries == nil {
+		summaries = []journal.Summary{}
+	}
+	return map[string][]journal.Summary{"days": summaries}, nil
+}
+
+// day returns one day
+func (s *Server) day(r *http.Request) (any, error) {
+	date, err := parseDay(r.PathValue("date"))
+	if err != nil {
+		return nil, err
+	}
+	return s.j.Day(date)
+}
+
+// appendDay adds {"text": "..."} to the end of a day, like plan add, and returns the day
+func (s *Server) appendDay(r *http.Request) (any, error) {
+	date, err := parseDay(r.PathValue("date"))
+	if err != nil {
+		return nil, err
+	}
+
+	var body struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, httpError(http.StatusBadRequest, "invalid request body: %v", err)
+	}
+	text := strings.TrimRight(body.Text, "\r\n")
+	if strings.TrimSpace(text) == "" {
+		return nil, httpError(http.StatusBadRequest, "text must not be empty")
+	}
+
+	if err := s.j.Append(date, text); err != nil {
+		return nil, err
+	}
+	s.changed(Change{
+		File:  filepath.Join(s.j.Dir(), dateutil.MonthFileName(date)),
+		Date:  dateutil.FormatDate(date),
+		Lines: strings.Count(text, "\n") + 1,
+	})
+	return s.j.Day(date)
+}
+
+// search returns the days matching ?q=, like plan search
+func (s *Server) search(r *http.Request) (any, error) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		return nil, httpError(http.StatusBadRequest, "missing search query (?q=)")
+	}
+
+	matches, err := s.j.Search(query)
+	if err != nil {
+		return nil, err
+	}
+	if matches == nil {
+		matches = []journal.Match{}
+	}
+	return map[string][]journal.Match{"matches": matches}, nil
+}
+
+// format formats a month file, like plan format
+// The response names the month rather than the file, so the plans directory isn't disclosed
+func (s *Server) format(r *http.Request) (any, error) {
+	month := r.PathValue("month")
+	if !dateutil.IsValidMonth(month) {
+		return nil, httpError(http.StatusBadRequest, "expected a month (YYYY-MM): %s", month)
+	}
+
+	result, err := s.j.Format(month)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Changes) > 0 {
+		s.changed(Change{File: result.File, Date: month})
+	}
+	return map[string]any{"month": month, "changes": result.Changes}, nil
+}
+
+// changed reports a change to the Changed callback, if any
+func (s *Server) changed(c Change) {
+	if s.opts.Changed != nil {
+		s.opts.Changed(c)
+	}
+}
@@ -0,0 +1,221 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/abyss/plan-journal-cli/pkg/journal"
+)
+
+const testFeb = `# 2026-02
+
+## 2026-02-14
+* Valentine's #errands
+
+## 2026-02-13 - Planning
+* [ ] Write the report
+`
+
+// testServer serves a journal with an out-of-order February
+func testServer(t *testing.T, opts Options) (*Server, string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "2026-02.plan"), []byte(testFeb), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	j, err := journal.Open(tmpDir, journal.Options{})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	return New(j, opts), tmpDir
+}
+
+// newRequest builds a request addressed to the server on localhost
+func newRequest(method, path string, body io.Reader) *http.Request {
+	return httptest.NewRequest(method, "http://localhost:8080"+path, body)
+}
+
+// do sends a request and decodes the JSON response into out, returning the status
+func do(t *testing.T, s *Server, method, path, body string, out any) int {
+	t.Helper()
+	req := newRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: invalid JSON %q: %v", method, path, rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+func TestReadEndpoints(t *testing.T) {
+	s, _ := testServer(t, Options{})
+
+	var months struct{ Months []string }
+	if status := do(t, s, "GET", "/api/months", "", &months); status != 200 || !reflect.DeepEqual(months.Months, []string{"2026-02"}) {
+		t.Errorf("GET /api/months = %d %+v", status, months)
+	}
+
+	var days struct{ Days []journal.Summary }
+	if status := do(t, s, "GET", "/api/days?filter=2026-02", "", &days); status != 200 || len(days.Days) != 2 || days.Days[0].Title != "Planning" {
+		t.Errorf("GET /api/days = %d %+v", status, days)
+	}
+
+	var day journal.Day
+	if status := do(t, s, "GET", "/api/days/2026-02-13", "", &day); status != 200 || day.Title != "Planning" || day.Lines[0] != "* [ ] Write the report" {
+		t.Errorf("GET /api/days/2026-02-13 = %d %+v", status, day)
+	}
+
+	var matches struct{ Matches []journal.Match }
+	if status := do(t, s, "GET", "/api/search?q=report", "", &matches); status != 200 || len(matches.Matches) != 1 || matches.Matches[0].Date != "2026-02-13" {
+		t.Errorf("GET /api/search = %d %+v", status, matches)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	s, _ := testServer(t, Options{})
+
+	tests := []struct {
+		method, path, body string
+		want               int
+	}{
+		{"GET", "/api/days/2026-02-20", "", http.StatusNotFound},
+		{"GET", "/api/days/2026-02", "", http.StatusBadRequest},
+		{"GET", "/api/days/someday", "", http.StatusBadRequest},
+		{"GET", "/api/days?filter=nope", "", http.StatusBadRequest},
+		{"GET", "/api/search", "", http.StatusBadRequest},
+		{"POST", "/api/days/2026-02-13", `{"text": "## 2026-02-20"}`, http.StatusBadRequest},
+		{"POST", "/api/days/2026-02-13", `{"text": " "}`, http.StatusBadRequest},
+		{"POST", "/api/days/2026-02-13", `not json`, http.StatusBadRequest},
+		{"POST", "/api/format/secrets.plan", `{}`, http.StatusBadRequest},
+		{"POST", "/api/format/2026-02-13", `{}`, http.StatusBadRequest},
+		{"GET", "/api/nope", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		var body struct{ Error string }
+		if status := do(t, s, tt.method, tt.path, tt.body, &body); status != tt.want || body.Error == "" {
+			t.Errorf("%s %s = %d %+v, want %d with an error", tt.method, tt.path, status, body, tt.want)
+		}
+	}
+}
+
+func TestAppendAndFormat(t *testing.T) {
+	var changes []Change
+	s, dir := testServer(t, Options{Changed: func(c Change) { changes = append(changes, c) }})
+
+	var day journal.Day
+	status := do(t, s, "POST", "/api/days/2026-02-13", `{"text": "* Called the bank\n* Two"}`, &day)
+	if status != 200 || !reflect.DeepEqual(day.Lines, []string{"* [ ] Write the report", "* Called the bank", "* Two"}) {
+		t.Errorf("POST /api/days/2026-02-13 = %d %+v", status, day)
+	}
+
+	// Appending leaves the days in the order they were written
+	var result map[string]any
+	status = do(t, s, "POST", "/api/format/2026-02", `{}`, &result)
+	want := map[string]any{"month": "2026-02", "changes": []any{"Reordered date sections chronologically"}}
+	if status != 200 || !reflect.DeepEqual(result, want) {
+		t.Errorf("POST /api/format/2026-02 = %d %+v", status, result)
+	}
+
+	file := filepath.Join(dir, "2026-02.plan")
+	wantChanges := []Change{{File: file, Date: "2026-02-13", Lines: 2}, {File: file, Date: "2026-02"}}
+	if !reflect.DeepEqual(changes, wantChanges) {
+		t.Errorf("Changed calls = %+v, want %+v", changes, wantChanges)
+	}
+
+	// A form post (which a web page could send from another site) is rejected
+	req := newRequest("POST", "/api/days/2026-02-13", strings.NewReader("text=hi"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("form POST = %d, want %d", rec.Code, http.StatusUnsupportedMediaType)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	s, dir := testServer(t, Options{ReadOnly: true})
+
+	if status := do(t, s, "POST", "/api/days/2026-02-13", `{"text": "* No"}`, nil); status != http.StatusForbidden {
+		t.Errorf("POST in read-only mode = %d, want %d", status, http.StatusForbidden)
+	}
+	if status := do(t, s, "POST", "/api/format/2026-02", `{}`, nil); status != http.StatusForbidden {
+		t.Errorf("format in read-only mode = %d, want %d", status, http.StatusForbidden)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "2026-02.plan")); string(data) != testFeb {
+		t.Errorf("read-only server changed the file:\n%s", data)
+	}
+
+	var info struct {
+		ReadOnly bool `json:"read_only"`
+	}
+	if status := do(t, s, "GET", "/api/info", "", &info); status != 200 || !info.ReadOnly {
+		t.Errorf("GET /api/info = %d %+v", status, info)
+	}
+}
+
+func TestToken(t *testing.T) {
+	s, _ := testServer(t, Options{Token: "s3cret"})
+
+	for _, auth := range []string{"", "Bearer wrong", "s3cret"} {
+		req := newRequest("GET", "/api/months", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("Authorization %q = %d, want %d", auth, rec.Code, http.StatusUnauthorized)
+		}
+	}
+
+	req := newRequest("GET", "/api/months", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("with the token = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	// The UI itself holds no journal data and loads without the token
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, newRequest("GET", "/", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "<title>plan</title>") {
+		t.Errorf("GET / = %d", rec.Code)
+	}
+}
+
+func TestHosts(t *testing.T) {
+	s, _ := testServer(t, Options{Hosts: []string{"journal.lan"}})
+
+	for host, want := range map[string]int{
+		"localhost:8080":       http.StatusOK,
+		"127.0.0.1:8080":       http.StatusOK,
+		"[::1]:8080":           http.StatusOK,
+		"192.168.1.20":         http.StatusOK,
+		"Journal.LAN:8080":     http.StatusOK,
+		"journal.lan.":         http.StatusOK,
+		"attacker.example":     http.StatusMisdirectedRequest,
+		"localhost.example:80": http.StatusMisdirectedRequest,
+	} {
+		req := newRequest("GET", "/api/months", nil)
+		req.Host = host
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("Host %q = %d, want %d", host, rec.Code, want)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package vault
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Extension is appended to the names of encrypted plan files (YYYY-MM.plan.enc)
+const Extension = ".enc"
+
+// KeyFileName is the file in an encrypted plans directory that verifies the passphrase
+const KeyFileName = ".plan-key"
+
+// Encrypted file layout:
+//
+//	magic (8) | log2(N) r p (3) | salt (16) | nonce (12) | AES-256-GCM ciphertext
+//
+// Everything before the ciphertext is authenticated as additional data.
+const (
+	magic      = "PLANENC1"
+	saltSize   = 16
+	nonceSize  = 12
+	keySize    = 32
+	headerSize = len(magic) + 3 + saltSize + nonceSize
+)
+
+// Limits on the key parameters of files being read, so a crafted header can't make
+// key derivation take minutes or gigabytes before failing. DefaultParams uses 32 MiB
+const (
+	maxMemory = 256 << 20 // Bytes scrypt needs: 128 * N * r
+	maxWork   = 16        // r * p, which multiplies the time taken
+)
+
+// keyCheck is the plaintext of the key file
+const keyCheck = "plan journal key"
+
+var (
+	// ErrWrongPassphrase is returned when data can't be decrypted with the passphrase
+	ErrWrongPassphrase = errors.New("wrong passphrase or corrupted file")
+	// ErrNotEncrypted is returned when data isn't in the encrypted format
+	ErrNotEncrypted = errors.New("not an encrypted plan file")
+)
+
+// Params are the scrypt cost parameters
+type Params struct {
+	LogN uint8 // CPU/memory cost as a power of two
+	R    uint8 // Block size
+	P    uint8 // Parallelism
+}
+
+// DefaultParams are the scrypt parameters for new keys (N=2^15, r=8, p=1)
+var DefaultParams = Params{LogN: 15, R: 8, P: 1}
+
+// Keyring derives keys from a passphrase and caches them, so a journal whose files
+// share a salt only pays for key derivation once
+// A Keyring is safe for concurrent use
+type Keyring struct {
+	passphrase string
+	params     Params
+	salt       []byte // Salt for newly encrypted data
+
+	mu   sync.Mutex
+	keys map[string][]byte // Derived keys by params and salt
+}
+
+// NewKeyring creates a keyring with a fresh salt and returns it with the contents
+// of a key file that OpenKeyring can later verify the passphrase against
+func NewKeyring(passphrase string) (*Keyring, []byte, error) {
+	if passphrase == "" {
+		return nil, nil, errors.New("passphrase must not be empty")
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	k := &Keyring{passphrase: passphrase, params: DefaultParams, salt: salt, keys: make(map[string][]byte)}
+	keyFile, err := k.Encrypt([]byte(keyCheck))
+	if err != nil {
+		return nil, nil, err
+	}
+	return k, keyFile, nil
+}
+
+// OpenKeyring checks a passphrase against a key file created by NewKeyring
+// New data is encrypted with the key file's salt and parameters
+func OpenKeyring(passphrase string, keyFile []byte) (*Keyring, error) {
+	params, salt, err := parseHeader(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	k := &Keyring{passphrase: passphrase, params: params, salt: salt, keys: make(map[string][]byte)}
+	check, err := k.Decrypt(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	if string(check) != keyCheck {
+		return nil, ErrWrongPassphrase
+	}
+	return k, nil
+}
+
+// Encrypt seals plaintext with a fresh nonce
+func (k *Keyring) Encrypt(plaintext []byte) ([]byte, error) {
+	key, err := k.key(k.params, k.salt)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, headerSize)
+	header = append(header, magic...)
+	header = append(header, k.params.LogN, k.params.R, k.params.P)
+	header = append(header, k.salt...)
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	header = append(header, nonce...)
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(header, nonce, plaintext, header), nil
+}
+
+// Decrypt opens data produced by Encrypt
+func (k *Keyring) Decrypt(data []byte) ([]byte, error) {
+	params, salt, err := parseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	key, err := k.key(params, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	header := data[:headerSize]
+	nonce := header[headerSize-nonceSize:]
+	plaintext, err := aead.Open(nil, nonce, data[headerSize:], header)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
+
+// IsEncrypted reports whether data starts with the encrypted file header
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(magic))
+}
+
+// key returns the derived key for params and salt, deriving it on first use
+func (k *Keyring) key(params Params, salt []byte) ([]byte, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	id := string([]byte{params.LogN, params.R, params.P}) + string(salt)
+	if key, ok := k.keys[id]; ok {
+		return key, nil
+	}
+
+	key, err := scrypt.Key([]byte(k.passphrase), salt, 1<<params.LogN, int(params.R), int(params.P), keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	k.keys[id] = key
+	return key, nil
+}
+
+// parseHeader returns the key derivation parameters and salt of encrypted data
+func parseHeader(data []byte) (Params, []byte, error) {
+	if !IsEncrypted(data) || len(data) < headerSize {
+		return Params{}, nil, ErrNotEncrypted
+	}
+	rest := data[len(magic):]
+	params := Params{LogN: rest[0], R: rest[1], P: rest[2]}
+	if !params.supported() {
+		return Params{}, nil, fmt.Errorf("unsupported key parameters in encrypted file")
+	}
+	return params, rest[3 : 3+saltSize], nil
+}
+
+// supported reports whether params are within the limits for reading files
+func (p Params) supported() bool {
+	if p.LogN < 10 || p.LogN > 20 || p.R == 0 || p.P == 0 {
+		return false
+	}
+	return 128*(uint64(1)<<p.LogN)*uint64(p.R) <= maxMemory && int(p.R)*int(p.P) <= maxWork
+}
+
+// newAEAD returns AES-256-GCM for a key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// SecureRemove overwrites a file with zeros before removing it
+// This is best effort: SSDs and copy-on-write filesystems may keep old blocks
+func SecureRemove(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if file, err := os.OpenFile(path, os.O_WRONLY, 0); err == nil {
+		_, _ = file.Write(make([]byte, info.Size()))
+		_ = file.Sync()
+		file.Close()
+	}
+	return os.Remove(path)
+}
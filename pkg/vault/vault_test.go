@@ -0,0 +1,106 @@
+package vault
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	k, keyFile, err := NewKeyring("correct horse")
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+
+	plaintext := []byte("# 2026-02\n\n## 2026-02-13\n* One-on-one notes\n")
+	sealed, err := k.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if !IsEncrypted(sealed) || bytes.Contains(sealed, []byte("One-on-one")) {
+		t.Fatalf("Encrypt() output doesn't look encrypted: %q", sealed)
+	}
+
+	// Same plaintext encrypts differently each time
+	again, _ := k.Encrypt(plaintext)
+	if bytes.Equal(sealed, again) {
+		t.Error("Encrypt() reused a nonce")
+	}
+
+	// A keyring reopened from the key file decrypts what the first one wrote
+	opened, err := OpenKeyring("correct horse", keyFile)
+	if err != nil {
+		t.Fatalf("OpenKeyring() error = %v", err)
+	}
+	got, err := opened.Decrypt(sealed)
+	if err != nil || !bytes.Equal(got, plaintext) {
+		t.Fatalf("Decrypt() = %q, %v, want the plaintext", got, err)
+	}
+
+	if _, err := OpenKeyring("wrong", keyFile); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("OpenKeyring(wrong passphrase) error = %v, want ErrWrongPassphrase", err)
+	}
+
+	// Tampering with the ciphertext or the authenticated header is detected
+	for _, i := range []int{len(sealed) - 1, len(magic) + 5} {
+		tampered := bytes.Clone(sealed)
+		tampered[i] ^= 1
+		if _, err := opened.Decrypt(tampered); err == nil {
+			t.Errorf("Decrypt() accepted data tampered at byte %d", i)
+		}
+	}
+
+	if _, err := opened.Decrypt(plaintext); !errors.Is(err, ErrNotEncrypted) {
+		t.Errorf("Decrypt(plaintext) error = %v, want ErrNotEncrypted", err)
+	}
+	if _, _, err := NewKeyring(""); err == nil {
+		t.Error("NewKeyring(\"\") succeeded, want an error")
+	}
+}
+
+func TestKeyParameterLimits(t *testing.T) {
+	salt := bytes.Repeat([]byte{1}, saltSize)
+	header := func(p Params) []byte {
+		data := append([]byte(magic), p.LogN, p.R, p.P)
+		data = append(data, salt...)
+		return append(data, make([]byte, nonceSize+32)...)
+	}
+
+	for _, p := range []Params{DefaultParams, {LogN: 10, R: 8, P: 1}, {LogN: 18, R: 8, P: 2}} {
+		if _, _, err := parseHeader(header(p)); err != nil {
+			t.Errorf("parseHeader(%+v) error = %v", p, err)
+		}
+	}
+
+	// Each of these would need gigabytes or minutes of key derivation
+	for _, p := range []Params{
+		{LogN: 20, R: 255, P: 255},
+		{LogN: 20, R: 8, P: 1},
+		{LogN: 15, R: 8, P: 255},
+		{LogN: 9, R: 8, P: 1},
+		{LogN: 15, R: 0, P: 1},
+	} {
+		if _, err := OpenKeyring("pass", header(p)); err == nil || errors.Is(err, ErrWrongPassphrase) {
+			t.Errorf("OpenKeyring() with %+v error = %v, want unsupported parameters", p, err)
+		}
+	}
+}
+
+func TestSecureRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret.plan")
+	if err := os.WriteFile(path, []byte("secret"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SecureRemove(path); err != nil {
+		t.Fatalf("SecureRemove() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("file still exists after SecureRemove()")
+	}
+	if err := SecureRemove(path); err != nil {
+		t.Errorf("SecureRemove(missing) error = %v, want nil", err)
+	}
+}